@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kong ACL groups a SupabaseAPIKey can be placed in.
+const (
+	// APIKeyGroupAnon reaches the routes the anon key reaches.
+	APIKeyGroupAnon = "anon"
+	// APIKeyGroupAdmin also reaches the routes reserved for the service role
+	// key, such as the Auth admin API.
+	APIKeyGroupAdmin = "admin"
+)
+
+// SupabaseAPIKeySpec describes a named API key of a SupabaseProject.
+type SupabaseAPIKeySpec struct {
+	// ProjectRef names the SupabaseProject in the same namespace whose JWT
+	// secret signs the key.
+	// +kubebuilder:validation:Required
+	ProjectRef corev1.LocalObjectReference `json:"projectRef"`
+
+	// Role is the Postgres role requests made with the key run as. It
+	// becomes the role claim of the token, so it may name a custom role.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Role string `json:"role"`
+
+	// Group is the Kong ACL group of the key's consumer.
+	// +kubebuilder:validation:Enum=anon;admin
+	// +kubebuilder:default=anon
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Claims are added to the token next to the claims the operator sets.
+	// role, iss, iat, exp and jti cannot be overridden.
+	// +optional
+	Claims map[string]string `json:"claims,omitempty"`
+
+	// ExpiresAt is when the key stops being accepted. Defaults to
+	// spec.jwt.apiKeyTTL of the project after the key was created.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// SecretName is the Secret the key is written to, under the api-key key.
+	// Defaults to the name of the SupabaseAPIKey.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// SupabaseAPIKeyStatus defines the observed state of SupabaseAPIKey.
+type SupabaseAPIKeyStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// SecretName is the Secret holding the key.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+
+	// KeyID is the jti claim of the current token. It changes whenever the
+	// key is signed again, for example when a JWT rotation's grace period
+	// ends.
+	// +optional
+	KeyID string `json:"keyId,omitempty"`
+
+	// ExpiresAt is the exp claim of the current token.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Project",type=string,JSONPath=`.spec.projectRef.name`
+// +kubebuilder:printcolumn:name="Role",type=string,JSONPath=`.spec.role`
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.status.expiresAt`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// SupabaseAPIKey is the Schema for the supabaseapikeys API
+type SupabaseAPIKey struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the desired state of SupabaseAPIKey
+	// +required
+	Spec SupabaseAPIKeySpec `json:"spec"`
+
+	// status defines the observed state of SupabaseAPIKey
+	// +optional
+	Status SupabaseAPIKeyStatus `json:"status,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SupabaseAPIKeyList contains a list of SupabaseAPIKey
+type SupabaseAPIKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SupabaseAPIKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SupabaseAPIKey{}, &SupabaseAPIKeyList{})
+}
@@ -2,7 +2,9 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type SupabaseProjectSpec struct {
@@ -16,6 +18,9 @@ type SupabaseProjectSpec struct {
 	// +kubebuilder:validation:Required
 	Storage StorageConfig `json:"storage"`
 
+	// +optional
+	JWT *JWTConfig `json:"jwt,omitempty"`
+
 	// +optional
 	Kong *KongConfig `json:"kong,omitempty"`
 
@@ -31,6 +36,21 @@ type SupabaseProjectSpec struct {
 	// +optional
 	StorageAPI *StorageAPIConfig `json:"storageApi,omitempty"`
 
+	// +optional
+	Imgproxy *ImgproxyConfig `json:"imgproxy,omitempty"`
+
+	// +optional
+	Functions *FunctionsConfig `json:"functions,omitempty"`
+
+	// +optional
+	Pooler *PoolerConfig `json:"pooler,omitempty"`
+
+	// +optional
+	Analytics *AnalyticsConfig `json:"analytics,omitempty"`
+
+	// +optional
+	Vector *VectorConfig `json:"vector,omitempty"`
+
 	// +optional
 	Meta *MetaConfig `json:"meta,omitempty"`
 
@@ -39,6 +59,9 @@ type SupabaseProjectSpec struct {
 
 	// +optional
 	Ingress *IngressConfig `json:"ingress,omitempty"`
+
+	// +optional
+	Gateway *GatewayConfig `json:"gateway,omitempty"`
 }
 
 type DatabaseConfig struct {
@@ -77,8 +100,91 @@ type DatabaseConfig struct {
 	MaxConnections int `json:"maxConnections,omitempty"`
 }
 
+// JWTConfig configures the secret used to sign and verify API keys and user
+// tokens. By default the operator generates a random secret.
+type JWTConfig struct {
+	// SecretRef references a Secret holding an existing JWT secret, so that
+	// projects migrated from another Supabase deployment keep accepting the
+	// tokens issued there.
+	//
+	// The Secret must contain the following keys:
+	//   - jwt-secret: Base64-encoded JWT signing secret
+	//
+	// And may contain:
+	//   - anon-key: Anon API key signed with jwt-secret
+	//   - service-role-key: Service role API key signed with jwt-secret
+	//
+	// Keys that are not supplied are derived from jwt-secret. The values are
+	// copied into the operator-managed <project>-jwt Secret.
+	// +optional
+	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+
+	// Rotation replaces the operator-generated JWT secret and API keys on
+	// request. It cannot be combined with secretRef.
+	// +optional
+	Rotation *JWTRotationConfig `json:"rotation,omitempty"`
+
+	// SigningAlgorithm is the algorithm Auth signs user access tokens with.
+	// ES256 and RS256 make the operator generate a signing key and publish
+	// its public half at /auth/v1/.well-known/jwks.json. The anon and service
+	// role API keys stay HS256 tokens signed with the JWT secret so that
+	// every component keeps accepting them.
+	// +kubebuilder:validation:Enum=HS256;ES256;RS256
+	// +kubebuilder:default=HS256
+	// +optional
+	SigningAlgorithm string `json:"signingAlgorithm,omitempty"`
+
+	// APIKeyTTL is the lifetime of the anon and service role keys in
+	// seconds. Keys that expire later than this, for example after the TTL
+	// was lowered, are re-minted.
+	// +kubebuilder:default=315360000
+	// +kubebuilder:validation:Minimum=86400
+	// +kubebuilder:validation:Maximum=315360000
+	// +optional
+	APIKeyTTL *int32 `json:"apiKeyTTL,omitempty"`
+
+	// APIKeyRenewBeforeSeconds is how long before their expiry the anon and
+	// service role keys are re-minted. Keys supplied through secretRef are
+	// never re-minted; a warning Event is recorded instead. Must be less than
+	// apiKeyTTL.
+	// +kubebuilder:default=2592000
+	// +kubebuilder:validation:Minimum=3600
+	// +optional
+	APIKeyRenewBeforeSeconds *int32 `json:"apiKeyRenewBeforeSeconds,omitempty"`
+}
+
+// RotateJWTAnnotation requests a JWT secret rotation when set on a
+// SupabaseProject. Every new value starts another rotation, so a timestamp is
+// a convenient value.
+const RotateJWTAnnotation = "supabase.strrl.dev/rotate-jwt"
+
+// JWTRotationConfig configures rotation of the generated JWT secret.
+type JWTRotationConfig struct {
+	// Trigger starts a rotation whenever it is set to a new value. The
+	// supabase.strrl.dev/rotate-jwt annotation has the same effect.
+	// +optional
+	Trigger string `json:"trigger,omitempty"`
+
+	// GracePeriodSeconds is how long tokens and API keys signed with the
+	// previous secret stay valid in the components that support a fallback
+	// secret. Zero drops the previous secret immediately.
+	// +kubebuilder:default=86400
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=2592000
+	// +optional
+	GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
+}
+
 type StorageConfig struct {
+	// Backend selects where Storage keeps objects. The file backend stores them
+	// on a PersistentVolumeClaim and does not use secretRef.
+	// +kubebuilder:validation:Enum=s3;file
+	// +kubebuilder:default=s3
+	// +optional
+	Backend string `json:"backend,omitempty"`
+
 	// SecretRef references a Secret containing S3-compatible object storage credentials.
+	// Required when backend is s3.
 	//
 	// The Secret must contain the following keys:
 	//   - endpoint: S3-compatible endpoint URL (e.g., https://minio.default.svc.cluster.local:9000)
@@ -90,12 +196,38 @@ type StorageConfig struct {
 	// Note: The admission webhook validates that secretRef.name is not empty (format validation).
 	// The controller validates secret existence and required keys (content validation).
 	//
-	// +kubebuilder:validation:Required
-	SecretRef corev1.SecretReference `json:"secretRef"`
+	// +optional
+	SecretRef corev1.SecretReference `json:"secretRef,omitempty"`
 
+	// ForcePathStyle addresses buckets as <endpoint>/<bucket>. Set it to false
+	// for virtual-hosted style providers such as R2 or GCS interop.
 	// +kubebuilder:default=true
 	// +optional
-	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+	ForcePathStyle *bool `json:"forcePathStyle,omitempty"`
+
+	// File configures the PersistentVolumeClaim of the file backend.
+	// +optional
+	File *StorageFileConfig `json:"file,omitempty"`
+}
+
+// StorageFileConfig is the template of the <project>-storage-data
+// PersistentVolumeClaim. The claim is created once and is kept when the
+// backend is switched back to s3, so later template changes are not applied.
+type StorageFileConfig struct {
+	// StorageClassName of the claim. The cluster default is used when unset.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+
+	// +kubebuilder:default="10Gi"
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// AccessMode of the claim. ReadWriteMany is required to run more than one
+	// Storage API replica.
+	// +kubebuilder:validation:Enum=ReadWriteOnce;ReadWriteMany
+	// +kubebuilder:default=ReadWriteOnce
+	// +optional
+	AccessMode corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
 }
 
 type KongConfig struct {
@@ -114,6 +246,197 @@ type KongConfig struct {
 
 	// +optional
 	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+
+	// ExtraServices are upstreams Kong routes to next to the Supabase
+	// services, for example a microservice served under the same API host.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	ExtraServices []KongService `json:"extraServices,omitempty"`
+
+	// GlobalPlugins run on every request Kong handles.
+	// +optional
+	GlobalPlugins []KongPlugin `json:"globalPlugins,omitempty"`
+
+	// RateLimits limits the request rate of the built-in routes with Kong's
+	// rate-limiting plugin.
+	// +optional
+	RateLimits *KongRateLimits `json:"rateLimits,omitempty"`
+
+	// CORS configures the cors plugin of the built-in services. Without it,
+	// every origin is allowed.
+	// +optional
+	CORS *KongCORS `json:"cors,omitempty"`
+}
+
+// KongCORS is the CORS policy of the gateway, with optional overrides per
+// built-in route group.
+type KongCORS struct {
+	KongCORSPolicy `json:",inline"`
+
+	// Overrides replace fields of the policy for single route groups. Fields
+	// an override leaves unset keep the value of the policy.
+	// +optional
+	Overrides *KongCORSOverrides `json:"overrides,omitempty"`
+}
+
+// KongCORSPolicy holds the settings of Kong's cors plugin. Unset fields keep
+// the plugin's defaults.
+type KongCORSPolicy struct {
+	// Origins allowed to make cross-origin requests, such as
+	// https://app.example.com, or * for any origin. Kong also accepts
+	// regular expressions.
+	// +kubebuilder:validation:items:MinLength=1
+	// +optional
+	Origins []string `json:"origins,omitempty"`
+
+	// Methods allowed in cross-origin requests.
+	// +kubebuilder:validation:items:Enum=GET;HEAD;PUT;PATCH;POST;DELETE;OPTIONS;TRACE;CONNECT
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+
+	// Headers allowed in cross-origin requests. Defaults to the headers the
+	// preflight request asks for.
+	// +optional
+	Headers []string `json:"headers,omitempty"`
+
+	// Credentials allows cookies and the Authorization header to be sent.
+	// +optional
+	Credentials *bool `json:"credentials,omitempty"`
+
+	// MaxAgeSeconds is how long browsers may cache a preflight response.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxAgeSeconds *int32 `json:"maxAgeSeconds,omitempty"`
+}
+
+// KongCORSOverrides holds the CORS overrides per built-in route group.
+type KongCORSOverrides struct {
+	// +optional
+	Auth *KongCORSPolicy `json:"auth,omitempty"`
+
+	// +optional
+	Rest *KongCORSPolicy `json:"rest,omitempty"`
+
+	// +optional
+	GraphQL *KongCORSPolicy `json:"graphql,omitempty"`
+
+	// +optional
+	Storage *KongCORSPolicy `json:"storage,omitempty"`
+
+	// +optional
+	Realtime *KongCORSPolicy `json:"realtime,omitempty"`
+
+	// +optional
+	Functions *KongCORSPolicy `json:"functions,omitempty"`
+}
+
+// KongRateLimits holds a rate limit per built-in route group. Counters are
+// kept in memory by each Kong replica, so the effective limit scales with
+// kong.replicas.
+type KongRateLimits struct {
+	// Auth limits /auth/v1/.
+	// +optional
+	Auth *KongRateLimit `json:"auth,omitempty"`
+
+	// Rest limits /rest/v1/.
+	// +optional
+	Rest *KongRateLimit `json:"rest,omitempty"`
+
+	// GraphQL limits /graphql/v1.
+	// +optional
+	GraphQL *KongRateLimit `json:"graphql,omitempty"`
+
+	// Storage limits /storage/v1/.
+	// +optional
+	Storage *KongRateLimit `json:"storage,omitempty"`
+
+	// Realtime limits /realtime/v1/, counting websocket connections rather
+	// than messages.
+	// +optional
+	Realtime *KongRateLimit `json:"realtime,omitempty"`
+
+	// Functions limits /functions/v1/.
+	// +optional
+	Functions *KongRateLimit `json:"functions,omitempty"`
+}
+
+// KongRateLimit is the number of requests allowed per period. At least one
+// period must be set, and longer periods cannot allow fewer requests.
+type KongRateLimit struct {
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Second *int32 `json:"second,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Minute *int32 `json:"minute,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Hour *int32 `json:"hour,omitempty"`
+
+	// LimitBy counts requests per client IP or per Kong consumer. Every client
+	// of the anon or publishable key is the same consumer, so ip is the one
+	// that holds back a single abusive client.
+	// +kubebuilder:validation:Enum=ip;consumer
+	// +kubebuilder:default=ip
+	// +optional
+	LimitBy string `json:"limitBy,omitempty"`
+}
+
+// KongService is an upstream added to the declarative config of Kong.
+type KongService struct {
+	// Name of the Kong service. It must not clash with the built-in ones.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// URL of the upstream, e.g. http://billing.default.svc:8080/api.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// +kubebuilder:validation:MinItems=1
+	Routes []KongRoute `json:"routes"`
+
+	// Plugins run on the requests of this service only.
+	// +optional
+	Plugins []KongPlugin `json:"plugins,omitempty"`
+}
+
+// KongRoute matches requests to a KongService by path prefix.
+type KongRoute struct {
+	// Paths are path prefixes. They must not collide with the paths of the
+	// built-in services.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:items:Pattern=`^/`
+	Paths []string `json:"paths"`
+
+	// Methods restricts the route to these HTTP methods. Defaults to all.
+	// +optional
+	Methods []string `json:"methods,omitempty"`
+
+	// StripPath removes the matched prefix before proxying.
+	// +kubebuilder:default=true
+	// +optional
+	StripPath *bool `json:"stripPath,omitempty"`
+}
+
+// KongPlugin enables a Kong plugin. Plugins outside of the ones the operator
+// uses are added to KONG_PLUGINS, so they must be bundled with the Kong image.
+type KongPlugin struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9][-a-z0-9_]*$`
+	Name string `json:"name"`
+
+	// Config is passed to the plugin as is. Strings may reference environment
+	// variables of the Kong container, such as those from kong.extraEnv, as
+	// $NAME.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Config *runtime.RawExtension `json:"config,omitempty"`
 }
 
 type AuthConfig struct {
@@ -133,13 +456,203 @@ type AuthConfig struct {
 	// +optional
 	SMTPSecretRef *corev1.SecretReference `json:"smtpSecretRef,omitempty"`
 
+	// SiteURL is the default redirect target of auth emails. Defaults to the
+	// Ingress URL, or http://localhost:8000.
+	// +optional
+	SiteURL string `json:"siteURL,omitempty"`
+
+	// AdditionalRedirectURLs are extra allowed redirect targets. Wildcards are
+	// supported as in GOTRUE_URI_ALLOW_LIST.
+	// +optional
+	AdditionalRedirectURLs []string `json:"additionalRedirectURLs,omitempty"`
+
+	// +kubebuilder:default=3600
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=604800
+	// +optional
+	JWTExpirySeconds int32 `json:"jwtExpirySeconds,omitempty"`
+
+	// +kubebuilder:default=false
+	// +optional
+	DisableSignup bool `json:"disableSignup,omitempty"`
+
+	// EmailAutoconfirm defaults to true without smtpSecretRef and false with it.
 	// +optional
-	OAuthSecretRef *corev1.SecretReference `json:"oauthSecretRef,omitempty"`
+	EmailAutoconfirm *bool `json:"emailAutoconfirm,omitempty"`
+
+	// +kubebuilder:default=false
+	// +optional
+	PhoneAutoconfirm bool `json:"phoneAutoconfirm,omitempty"`
+
+	// +kubebuilder:default=false
+	// +optional
+	AnonymousSignIns bool `json:"anonymousSignIns,omitempty"`
+
+	// +kubebuilder:validation:Minimum=6
+	// +kubebuilder:validation:Maximum=72
+	// +optional
+	PasswordMinLength int32 `json:"passwordMinLength,omitempty"`
+
+	// +optional
+	RateLimits *AuthRateLimits `json:"rateLimits,omitempty"`
+
+	// +optional
+	MFA *AuthMFAConfig `json:"mfa,omitempty"`
+
+	// Hooks configures GoTrue auth hooks, at most one per type.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Hooks []AuthHook `json:"hooks,omitempty"`
+
+	// Providers configures external OAuth sign-in providers.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Providers []OAuthProvider `json:"providers,omitempty"`
+
+	// +optional
+	SAML *SAMLConfig `json:"saml,omitempty"`
+
+	// SMS enables phone sign-in with one-time passwords.
+	// +optional
+	SMS *SMSConfig `json:"sms,omitempty"`
 
 	// +optional
 	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
 }
 
+// AuthRateLimits overrides GoTrue rate limits. Unset fields keep the GoTrue
+// defaults.
+type AuthRateLimits struct {
+	// EmailSent is the number of emails sent per hour.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	EmailSent int32 `json:"emailSent,omitempty"`
+
+	// SMSSent is the number of SMS messages sent per hour.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	SMSSent int32 `json:"smsSent,omitempty"`
+
+	// Verify is the number of verification requests per 5 minutes per IP.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Verify int32 `json:"verify,omitempty"`
+
+	// TokenRefresh is the number of token refreshes per 5 minutes per IP.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TokenRefresh int32 `json:"tokenRefresh,omitempty"`
+
+	// OTP is the number of OTP requests per 5 minutes per IP.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	OTP int32 `json:"otp,omitempty"`
+
+	// AnonymousUsers is the number of anonymous sign-ins per hour per IP.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	AnonymousUsers int32 `json:"anonymousUsers,omitempty"`
+}
+
+// AuthMFAConfig toggles multi-factor authentication factors.
+type AuthMFAConfig struct {
+	// TOTP enables enrollment and verification of TOTP factors.
+	// +optional
+	TOTP *bool `json:"totp,omitempty"`
+
+	// Phone enables enrollment and verification of phone factors.
+	// +optional
+	Phone *bool `json:"phone,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxEnrolledFactors int32 `json:"maxEnrolledFactors,omitempty"`
+}
+
+// AuthHook points a GoTrue hook at a Postgres function or an HTTP endpoint.
+type AuthHook struct {
+	// +kubebuilder:validation:Enum=custom_access_token;send_email;send_sms;mfa_verification_attempt;password_verification_attempt;before_user_created
+	Type string `json:"type"`
+
+	// URI is pg-functions://<database>/<schema>/<function> or an http(s) URL.
+	// +kubebuilder:validation:MinLength=1
+	URI string `json:"uri"`
+
+	// SecretRef selects the standard-webhooks signing secret
+	// ("v1,whsec_<base64>"). Required for HTTP hooks.
+	// +optional
+	SecretRef *corev1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// SAMLConfig enables SAML 2.0 SSO in GoTrue.
+type SAMLConfig struct {
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// PrivateKeySecretRef selects a base64-encoded PKCS#1 DER RSA private key.
+	// When unset, the operator generates one in the <project>-saml Secret.
+	// +optional
+	PrivateKeySecretRef *corev1.SecretKeySelector `json:"privateKeySecretRef,omitempty"`
+}
+
+// SMSConfig configures the GoTrue SMS provider used for phone OTP login.
+type SMSConfig struct {
+	// +kubebuilder:validation:Enum=twilio;messagebird;textlocal;vonage
+	Provider string `json:"provider"`
+
+	// SecretRef holds the provider credentials. Required keys depend on the
+	// provider: twilio (accountSid, authToken, messageServiceSid), messagebird
+	// (accessKey, originator), textlocal (apiKey, sender), vonage (apiKey,
+	// apiSecret, from).
+	SecretRef corev1.SecretReference `json:"secretRef"`
+
+	// +kubebuilder:default=6
+	// +kubebuilder:validation:Minimum=6
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	OTPLength int32 `json:"otpLength,omitempty"`
+
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	OTPExpirySeconds int32 `json:"otpExpirySeconds,omitempty"`
+
+	// Template is the message body; {{ .Code }} is replaced with the OTP.
+	// +optional
+	Template string `json:"template,omitempty"`
+}
+
+// OAuthProvider configures one GoTrue external provider. Each entry is rendered
+// as GOTRUE_EXTERNAL_<NAME>_* environment variables.
+type OAuthProvider struct {
+	// +kubebuilder:validation:Enum=apple;azure;bitbucket;discord;facebook;figma;github;gitlab;google;kakao;keycloak;linkedin_oidc;notion;slack_oidc;spotify;twitch;twitter;workos;zoom
+	Name string `json:"name"`
+
+	// +kubebuilder:default=false
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientId"`
+
+	// SecretRef selects the key holding the provider client secret. The
+	// Secret must live in the project namespace.
+	SecretRef corev1.SecretKeySelector `json:"secretRef"`
+
+	// RedirectURI defaults to <external URL>/auth/v1/callback.
+	// +optional
+	RedirectURI string `json:"redirectUri,omitempty"`
+
+	// URL of a self-hosted provider (required for keycloak, optional for
+	// azure, gitlab and workos).
+	// +optional
+	URL string `json:"url,omitempty"`
+}
+
 type RealtimeConfig struct {
 	// +kubebuilder:default="supabase/realtime:v2.102.3"
 	// +optional
@@ -172,6 +685,42 @@ type PostgRESTConfig struct {
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
 
+	// ExposedSchemas are served by the REST API (PGRST_DB_SCHEMAS). Defaults
+	// to public. When set without graphql_public, the Kong GraphQL route is
+	// dropped.
+	// +optional
+	ExposedSchemas []string `json:"exposedSchemas,omitempty"`
+
+	// ExtraSearchPath is added to the search path (PGRST_DB_EXTRA_SEARCH_PATH).
+	// Defaults to public.
+	// +optional
+	ExtraSearchPath []string `json:"extraSearchPath,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRows int32 `json:"maxRows,omitempty"`
+
+	// DBPool is the connection pool size per replica. Defaults to 10, capped so
+	// that all replicas fit in database.maxConnections.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DBPool int32 `json:"dbPool,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DBPoolAcquisitionTimeout int32 `json:"dbPoolAcquisitionTimeout,omitempty"`
+
+	// PreRequest is a function called before every request.
+	// +optional
+	PreRequest string `json:"preRequest,omitempty"`
+
+	// +kubebuilder:validation:Enum=follow-privileges;ignore-privileges;disabled
+	// +optional
+	OpenAPIMode string `json:"openapiMode,omitempty"`
+
+	// +optional
+	ServerTimingEnabled bool `json:"serverTimingEnabled,omitempty"`
+
 	// +optional
 	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
 }
@@ -190,6 +739,250 @@ type StorageAPIConfig struct {
 	// +optional
 	Replicas int32 `json:"replicas,omitempty"`
 
+	// FileSizeLimit is the maximum upload size in bytes.
+	// +kubebuilder:default=52428800
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FileSizeLimit int64 `json:"fileSizeLimit,omitempty"`
+
+	// +kubebuilder:default="stub"
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+
+	// UploadSignedURLExpiry is the lifetime of signed upload URLs in seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	UploadSignedURLExpiry int32 `json:"uploadSignedUrlExpiry,omitempty"`
+
+	// +optional
+	TUS *StorageTUSConfig `json:"tus,omitempty"`
+
+	// S3ProtocolSecretRef enables the S3-compatible endpoint of Storage. The
+	// Secret must contain accessKeyId and secretAccessKey.
+	// +optional
+	S3ProtocolSecretRef *corev1.SecretReference `json:"s3ProtocolSecretRef,omitempty"`
+
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+}
+
+// StorageTUSConfig tunes resumable (TUS) uploads. Unset fields keep the
+// Storage API defaults.
+type StorageTUSConfig struct {
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	URLExpirySeconds int32 `json:"urlExpirySeconds,omitempty"`
+
+	// PartSizeMB is the size of each part uploaded to the backend.
+	// +kubebuilder:validation:Minimum=5
+	// +optional
+	PartSizeMB int32 `json:"partSizeMB,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentUploads int32 `json:"maxConcurrentUploads,omitempty"`
+}
+
+// ImgproxyConfig deploys imgproxy next to the Storage API to serve
+// /storage/v1/render/image transformations.
+type ImgproxyConfig struct {
+	// +kubebuilder:default="darthsim/imgproxy:v3.8.0"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+}
+
+// FunctionsConfig deploys the Supabase edge runtime and serves it under
+// /functions/v1/. Function code comes from per-function ConfigMaps, or from a
+// PersistentVolumeClaim or OCI image holding one directory per function.
+type FunctionsConfig struct {
+	// +kubebuilder:default="supabase/edge-runtime:v1.70.3"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// VerifyJWT is the default for functions that do not set their own.
+	// +kubebuilder:default=true
+	// +optional
+	VerifyJWT *bool `json:"verifyJWT,omitempty"`
+
+	// Source holds the code of every function when it is not loaded from
+	// per-function ConfigMaps.
+	// +optional
+	Source *FunctionsSource `json:"source,omitempty"`
+
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Functions []EdgeFunction `json:"functions,omitempty"`
+
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+}
+
+// FunctionsSource is a volume laid out as <name>/index.ts per function.
+// Exactly one field must be set.
+type FunctionsSource struct {
+	// +optional
+	PersistentVolumeClaim *FunctionsPVCSource `json:"persistentVolumeClaim,omitempty"`
+
+	// Image mounts an OCI artifact as an image volume. It requires the
+	// ImageVolume feature of Kubernetes.
+	// +optional
+	Image *FunctionsImageSource `json:"image,omitempty"`
+}
+
+type FunctionsPVCSource struct {
+	// +kubebuilder:validation:Required
+	ClaimName string `json:"claimName"`
+
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+}
+
+type FunctionsImageSource struct {
+	// +kubebuilder:validation:Required
+	Reference string `json:"reference"`
+
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	// +optional
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+}
+
+type EdgeFunction struct {
+	// Name is the function slug served at /functions/v1/<name>.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-_a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// ConfigMapRef holds the function files, index.ts being the entrypoint.
+	// Required unless spec.functions.source is set.
+	// +optional
+	ConfigMapRef *corev1.LocalObjectReference `json:"configMapRef,omitempty"`
+
+	// VerifyJWT overrides spec.functions.verifyJWT for this function.
+	// +optional
+	VerifyJWT *bool `json:"verifyJWT,omitempty"`
+}
+
+// PoolerConfig deploys Supavisor in front of the user's PostgreSQL. It serves
+// session mode on port 5432 and transaction mode on port 6543.
+type PoolerConfig struct {
+	// +kubebuilder:default="supabase/supavisor:2.7.4"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// PoolMode is the default mode of the tenant's manager user.
+	// +kubebuilder:validation:Enum=transaction;session
+	// +kubebuilder:default=transaction
+	// +optional
+	PoolMode string `json:"poolMode,omitempty"`
+
+	// DefaultPoolSize is the number of server connections per user and database.
+	// +kubebuilder:default=20
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DefaultPoolSize int32 `json:"defaultPoolSize,omitempty"`
+
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxClientConnections int32 `json:"maxClientConnections,omitempty"`
+
+	// TenantID suffixes pooled user names, as in authenticator.<tenantId>.
+	// Defaults to spec.projectId.
+	// +optional
+	TenantID string `json:"tenantId,omitempty"`
+
+	// RouteComponents lists the components that reach PostgreSQL through the
+	// pooler. PostgREST uses the transaction port, Auth and Storage the session
+	// port.
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=postgrest;auth;storage
+	// +optional
+	RouteComponents []string `json:"routeComponents,omitempty"`
+
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+}
+
+// AnalyticsConfig deploys Logflare backed by the _analytics schema of the
+// _supabase database and turns on the Studio log explorer.
+type AnalyticsConfig struct {
+	// +kubebuilder:default="supabase/logflare:1.22.6"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// +optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+}
+
+// VectorConfig deploys Vector as a DaemonSet that tails the project's pod logs
+// from the node and ships them to Logflare. Requires analytics to be enabled.
+type VectorConfig struct {
+	// +kubebuilder:default="timberio/vector:0.28.1-alpine"
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Tolerations let Vector run on tainted nodes that host project pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
 	// +optional
 	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
 }
@@ -240,6 +1033,9 @@ type StudioConfig struct {
 	DashboardBasicAuthSecretRef *corev1.SecretReference `json:"dashboardBasicAuthSecretRef,omitempty"`
 }
 
+// IngressConfig describes the Ingress the operator creates in front of the
+// Kong proxy. When enabled with a host, that host also becomes the default
+// public URL for Studio, Auth and status.endpoints.
 type IngressConfig struct {
 	// +optional
 	Enabled bool `json:"enabled,omitempty"`
@@ -257,6 +1053,61 @@ type IngressConfig struct {
 	TLSSecretName string `json:"tlsSecretName,omitempty"`
 }
 
+// GatewayConfig describes the Gateway API HTTPRoute the operator creates in
+// front of the Kong proxy as an alternative to Ingress.
+type GatewayConfig struct {
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// ParentRefs are the Gateways, or individual Gateway listeners, the
+	// HTTPRoute attaches to. At least one is required when enabled.
+	// +optional
+	ParentRefs []GatewayParentReference `json:"parentRefs,omitempty"`
+
+	// +optional
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// TLS enables TLS between the Gateway and Kong. When set, the HTTPRoute
+	// targets Kong's proxy-ssl port and a BackendTLSPolicy is created for it.
+	// Kong must serve a certificate for the hostname below, e.g. through
+	// KONG_SSL_CERT/KONG_SSL_CERT_KEY in kong.extraEnv.
+	// +optional
+	TLS *GatewayTLSConfig `json:"tls,omitempty"`
+}
+
+type GatewayParentReference struct {
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the project namespace.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName selects a single listener on the Gateway.
+	// +optional
+	SectionName string `json:"sectionName,omitempty"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+type GatewayTLSConfig struct {
+	// Hostname is the SNI sent to Kong and the name its certificate must match.
+	// +kubebuilder:validation:Required
+	Hostname string `json:"hostname"`
+
+	// CACertificateRefs names ConfigMaps holding the CA bundle (key "ca.crt")
+	// used to verify Kong. Mutually exclusive with WellKnownCACertificates.
+	// +optional
+	CACertificateRefs []string `json:"caCertificateRefs,omitempty"`
+
+	// +kubebuilder:validation:Enum=System
+	// +optional
+	WellKnownCACertificates string `json:"wellKnownCACertificates,omitempty"`
+}
+
 type SupabaseProjectStatus struct {
 	// +optional
 	Phase string `json:"phase,omitempty"`
@@ -278,6 +1129,9 @@ type SupabaseProjectStatus struct {
 	// +optional
 	Endpoints EndpointsStatus `json:"endpoints,omitempty"`
 
+	// +optional
+	APIKeys APIKeysStatus `json:"apiKeys,omitempty"`
+
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 
@@ -301,6 +1155,27 @@ type ComponentsStatus struct {
 	// +optional
 	StorageAPI ComponentStatus `json:"storageApi,omitempty"`
 
+	// Imgproxy is only reported while the component is enabled.
+	// +optional
+	Imgproxy *ComponentStatus `json:"imgproxy,omitempty"`
+
+	// Functions is only reported while the component is enabled.
+	// +optional
+	Functions *ComponentStatus `json:"functions,omitempty"`
+
+	// Pooler is only reported while the component is enabled.
+	// +optional
+	Pooler *ComponentStatus `json:"pooler,omitempty"`
+
+	// Analytics is only reported while the component is enabled.
+	// +optional
+	Analytics *ComponentStatus `json:"analytics,omitempty"`
+
+	// Vector is only reported while the component is enabled. Replicas count
+	// the nodes the DaemonSet is scheduled on.
+	// +optional
+	Vector *ComponentStatus `json:"vector,omitempty"`
+
 	// +optional
 	Meta ComponentStatus `json:"meta,omitempty"`
 
@@ -371,6 +1246,37 @@ type EndpointsStatus struct {
 	REST string `json:"rest,omitempty"`
 }
 
+// APIKeysStatus lists the API keys of the project in both styles. The keys
+// meant for browsers and apps are shown as is; the privileged ones are only
+// referenced.
+type APIKeysStatus struct {
+	// PublishableKey is the opaque sb_publishable_ key, which replaces AnonKey.
+	// +optional
+	PublishableKey string `json:"publishableKey,omitempty"`
+
+	// AnonKey is the legacy JWT API key with the anon role.
+	// +optional
+	AnonKey string `json:"anonKey,omitempty"`
+
+	// AnonKeyExpiresAt is the exp claim of AnonKey.
+	// +optional
+	AnonKeyExpiresAt *metav1.Time `json:"anonKeyExpiresAt,omitempty"`
+
+	// SecretKeyRef selects the opaque sb_secret_ key, which replaces the
+	// service role key.
+	// +optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ServiceRoleKeyRef selects the legacy JWT API key with the service_role
+	// role.
+	// +optional
+	ServiceRoleKeyRef *corev1.SecretKeySelector `json:"serviceRoleKeyRef,omitempty"`
+
+	// ServiceRoleKeyExpiresAt is the exp claim of the service role key.
+	// +optional
+	ServiceRoleKeyExpiresAt *metav1.Time `json:"serviceRoleKeyExpiresAt,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 
@@ -120,6 +120,7 @@ func TestDatabaseConfig_SecretReference(t *testing.T) {
 }
 
 func TestStorageConfig_SecretReference(t *testing.T) {
+	forcePathStyle := true
 	tests := []struct {
 		name    string
 		config  StorageConfig
@@ -131,14 +132,14 @@ func TestStorageConfig_SecretReference(t *testing.T) {
 				SecretRef: corev1.SecretReference{
 					Name: "s3-config",
 				},
-				ForcePathStyle: true,
+				ForcePathStyle: &forcePathStyle,
 			},
 			wantErr: false,
 		},
 		{
 			name: "missing secret ref should fail",
 			config: StorageConfig{
-				ForcePathStyle: true,
+				ForcePathStyle: &forcePathStyle,
 			},
 			wantErr: true,
 		},
@@ -148,7 +149,7 @@ func TestStorageConfig_SecretReference(t *testing.T) {
 				SecretRef: corev1.SecretReference{
 					Name: "s3-config",
 				},
-				ForcePathStyle: true,
+				ForcePathStyle: &forcePathStyle,
 			},
 			wantErr: false,
 		},
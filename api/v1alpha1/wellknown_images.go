@@ -14,6 +14,11 @@ const (
 	DefaultPostgRESTImage  = "postgrest/postgrest:v14.12"
 	DefaultRealtimeImage   = "supabase/realtime:v2.102.3"
 	DefaultStorageAPIImage = "supabase/storage-api:v1.60.4"
+	DefaultImgproxyImage   = "darthsim/imgproxy:v3.8.0"
+	DefaultFunctionsImage  = "supabase/edge-runtime:v1.70.3"
+	DefaultPoolerImage     = "supabase/supavisor:2.7.4"
+	DefaultAnalyticsImage  = "supabase/logflare:1.22.6"
+	DefaultVectorImage     = "timberio/vector:0.28.1-alpine"
 	DefaultMetaImage       = "supabase/postgres-meta:v0.96.6"
 	DefaultStudioImage     = "supabase/studio:2026.07.07-sha-a6a04f2"
 )
@@ -5,32 +5,131 @@
 package v1alpha1
 
 import (
-	"k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeysStatus) DeepCopyInto(out *APIKeysStatus) {
+	*out = *in
+	if in.AnonKeyExpiresAt != nil {
+		in, out := &in.AnonKeyExpiresAt, &out.AnonKeyExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceRoleKeyRef != nil {
+		in, out := &in.ServiceRoleKeyRef, &out.ServiceRoleKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceRoleKeyExpiresAt != nil {
+		in, out := &in.ServiceRoleKeyExpiresAt, &out.ServiceRoleKeyExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeysStatus.
+func (in *APIKeysStatus) DeepCopy() *APIKeysStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeysStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnalyticsConfig) DeepCopyInto(out *AnalyticsConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnalyticsConfig.
+func (in *AnalyticsConfig) DeepCopy() *AnalyticsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AnalyticsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuthConfig) DeepCopyInto(out *AuthConfig) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.SMTPSecretRef != nil {
 		in, out := &in.SMTPSecretRef, &out.SMTPSecretRef
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
-	if in.OAuthSecretRef != nil {
-		in, out := &in.OAuthSecretRef, &out.OAuthSecretRef
-		*out = new(v1.SecretReference)
+	if in.AdditionalRedirectURLs != nil {
+		in, out := &in.AdditionalRedirectURLs, &out.AdditionalRedirectURLs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailAutoconfirm != nil {
+		in, out := &in.EmailAutoconfirm, &out.EmailAutoconfirm
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(AuthRateLimits)
+		**out = **in
+	}
+	if in.MFA != nil {
+		in, out := &in.MFA, &out.MFA
+		*out = new(AuthMFAConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]AuthHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]OAuthProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SAML != nil {
+		in, out := &in.SAML, &out.SAML
+		*out = new(SAMLConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SMS != nil {
+		in, out := &in.SMS, &out.SMS
+		*out = new(SMSConfig)
 		**out = **in
 	}
 	if in.ExtraEnv != nil {
 		in, out := &in.ExtraEnv, &out.ExtraEnv
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -47,12 +146,72 @@ func (in *AuthConfig) DeepCopy() *AuthConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthHook) DeepCopyInto(out *AuthHook) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthHook.
+func (in *AuthHook) DeepCopy() *AuthHook {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthMFAConfig) DeepCopyInto(out *AuthMFAConfig) {
+	*out = *in
+	if in.TOTP != nil {
+		in, out := &in.TOTP, &out.TOTP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Phone != nil {
+		in, out := &in.Phone, &out.Phone
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthMFAConfig.
+func (in *AuthMFAConfig) DeepCopy() *AuthMFAConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthMFAConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthRateLimits) DeepCopyInto(out *AuthRateLimits) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthRateLimits.
+func (in *AuthRateLimits) DeepCopy() *AuthRateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthRateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -81,6 +240,31 @@ func (in *ComponentsStatus) DeepCopyInto(out *ComponentsStatus) {
 	in.Realtime.DeepCopyInto(&out.Realtime)
 	in.PostgREST.DeepCopyInto(&out.PostgREST)
 	in.StorageAPI.DeepCopyInto(&out.StorageAPI)
+	if in.Imgproxy != nil {
+		in, out := &in.Imgproxy, &out.Imgproxy
+		*out = new(ComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = new(ComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pooler != nil {
+		in, out := &in.Pooler, &out.Pooler
+		*out = new(ComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Analytics != nil {
+		in, out := &in.Analytics, &out.Analytics
+		*out = new(ComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vector != nil {
+		in, out := &in.Vector, &out.Vector
+		*out = new(ComponentStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Meta.DeepCopyInto(&out.Meta)
 	in.Studio.DeepCopyInto(&out.Studio)
 }
@@ -131,114 +315,724 @@ func (in *DependenciesStatus) DeepCopy() *DependenciesStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DependencyStatus) DeepCopyInto(out *DependencyStatus) {
 	*out = *in
-	if in.LastConnectedTime != nil {
-		in, out := &in.LastConnectedTime, &out.LastConnectedTime
-		*out = (*in).DeepCopy()
+	if in.LastConnectedTime != nil {
+		in, out := &in.LastConnectedTime, &out.LastConnectedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyStatus.
+func (in *DependencyStatus) DeepCopy() *DependencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EdgeFunction) DeepCopyInto(out *EdgeFunction) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.VerifyJWT != nil {
+		in, out := &in.VerifyJWT, &out.VerifyJWT
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EdgeFunction.
+func (in *EdgeFunction) DeepCopy() *EdgeFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(EdgeFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointsStatus) DeepCopyInto(out *EndpointsStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointsStatus.
+func (in *EndpointsStatus) DeepCopy() *EndpointsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionsConfig) DeepCopyInto(out *FunctionsConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VerifyJWT != nil {
+		in, out := &in.VerifyJWT, &out.VerifyJWT
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(FunctionsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = make([]EdgeFunction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionsConfig.
+func (in *FunctionsConfig) DeepCopy() *FunctionsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionsImageSource) DeepCopyInto(out *FunctionsImageSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionsImageSource.
+func (in *FunctionsImageSource) DeepCopy() *FunctionsImageSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionsImageSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionsPVCSource) DeepCopyInto(out *FunctionsPVCSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionsPVCSource.
+func (in *FunctionsPVCSource) DeepCopy() *FunctionsPVCSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionsPVCSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FunctionsSource) DeepCopyInto(out *FunctionsSource) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(FunctionsPVCSource)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(FunctionsImageSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FunctionsSource.
+func (in *FunctionsSource) DeepCopy() *FunctionsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(FunctionsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayConfig) DeepCopyInto(out *GatewayConfig) {
+	*out = *in
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(GatewayTLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayConfig.
+func (in *GatewayConfig) DeepCopy() *GatewayConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayParentReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTLSConfig) DeepCopyInto(out *GatewayTLSConfig) {
+	*out = *in
+	if in.CACertificateRefs != nil {
+		in, out := &in.CACertificateRefs, &out.CACertificateRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSConfig.
+func (in *GatewayTLSConfig) DeepCopy() *GatewayTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImgproxyConfig) DeepCopyInto(out *ImgproxyConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImgproxyConfig.
+func (in *ImgproxyConfig) DeepCopy() *ImgproxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImgproxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
+	*out = *in
+	if in.ClassName != nil {
+		in, out := &in.ClassName, &out.ClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfig.
+func (in *IngressConfig) DeepCopy() *IngressConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTConfig) DeepCopyInto(out *JWTConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(JWTRotationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIKeyTTL != nil {
+		in, out := &in.APIKeyTTL, &out.APIKeyTTL
+		*out = new(int32)
+		**out = **in
+	}
+	if in.APIKeyRenewBeforeSeconds != nil {
+		in, out := &in.APIKeyRenewBeforeSeconds, &out.APIKeyRenewBeforeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTConfig.
+func (in *JWTConfig) DeepCopy() *JWTConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTRotationConfig) DeepCopyInto(out *JWTRotationConfig) {
+	*out = *in
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTRotationConfig.
+func (in *JWTRotationConfig) DeepCopy() *JWTRotationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTRotationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongCORS) DeepCopyInto(out *KongCORS) {
+	*out = *in
+	in.KongCORSPolicy.DeepCopyInto(&out.KongCORSPolicy)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(KongCORSOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongCORS.
+func (in *KongCORS) DeepCopy() *KongCORS {
+	if in == nil {
+		return nil
+	}
+	out := new(KongCORS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongCORSOverrides) DeepCopyInto(out *KongCORSOverrides) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(KongCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rest != nil {
+		in, out := &in.Rest, &out.Rest
+		*out = new(KongCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GraphQL != nil {
+		in, out := &in.GraphQL, &out.GraphQL
+		*out = new(KongCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(KongCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Realtime != nil {
+		in, out := &in.Realtime, &out.Realtime
+		*out = new(KongCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = new(KongCORSPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongCORSOverrides.
+func (in *KongCORSOverrides) DeepCopy() *KongCORSOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(KongCORSOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongCORSPolicy) DeepCopyInto(out *KongCORSPolicy) {
+	*out = *in
+	if in.Origins != nil {
+		in, out := &in.Origins, &out.Origins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxAgeSeconds != nil {
+		in, out := &in.MaxAgeSeconds, &out.MaxAgeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongCORSPolicy.
+func (in *KongCORSPolicy) DeepCopy() *KongCORSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(KongCORSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongConfig) DeepCopyInto(out *KongConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraServices != nil {
+		in, out := &in.ExtraServices, &out.ExtraServices
+		*out = make([]KongService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GlobalPlugins != nil {
+		in, out := &in.GlobalPlugins, &out.GlobalPlugins
+		*out = make([]KongPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		*out = new(KongRateLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(KongCORS)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongConfig.
+func (in *KongConfig) DeepCopy() *KongConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KongConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongPlugin) DeepCopyInto(out *KongPlugin) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongPlugin.
+func (in *KongPlugin) DeepCopy() *KongPlugin {
+	if in == nil {
+		return nil
+	}
+	out := new(KongPlugin)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongRateLimit) DeepCopyInto(out *KongRateLimit) {
+	*out = *in
+	if in.Second != nil {
+		in, out := &in.Second, &out.Second
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Minute != nil {
+		in, out := &in.Minute, &out.Minute
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Hour != nil {
+		in, out := &in.Hour, &out.Hour
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongRateLimit.
+func (in *KongRateLimit) DeepCopy() *KongRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(KongRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KongRateLimits) DeepCopyInto(out *KongRateLimits) {
+	*out = *in
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(KongRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rest != nil {
+		in, out := &in.Rest, &out.Rest
+		*out = new(KongRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GraphQL != nil {
+		in, out := &in.GraphQL, &out.GraphQL
+		*out = new(KongRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(KongRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Realtime != nil {
+		in, out := &in.Realtime, &out.Realtime
+		*out = new(KongRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = new(KongRateLimit)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyStatus.
-func (in *DependencyStatus) DeepCopy() *DependencyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongRateLimits.
+func (in *KongRateLimits) DeepCopy() *KongRateLimits {
 	if in == nil {
 		return nil
 	}
-	out := new(DependencyStatus)
+	out := new(KongRateLimits)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EndpointsStatus) DeepCopyInto(out *EndpointsStatus) {
+func (in *KongRoute) DeepCopyInto(out *KongRoute) {
 	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Methods != nil {
+		in, out := &in.Methods, &out.Methods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StripPath != nil {
+		in, out := &in.StripPath, &out.StripPath
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointsStatus.
-func (in *EndpointsStatus) DeepCopy() *EndpointsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongRoute.
+func (in *KongRoute) DeepCopy() *KongRoute {
 	if in == nil {
 		return nil
 	}
-	out := new(EndpointsStatus)
+	out := new(KongRoute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressConfig) DeepCopyInto(out *IngressConfig) {
+func (in *KongService) DeepCopyInto(out *KongService) {
 	*out = *in
-	if in.ClassName != nil {
-		in, out := &in.ClassName, &out.ClassName
-		*out = new(string)
-		**out = **in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]KongRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make([]KongPlugin, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressConfig.
-func (in *IngressConfig) DeepCopy() *IngressConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongService.
+func (in *KongService) DeepCopy() *KongService {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressConfig)
+	out := new(KongService)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KongConfig) DeepCopyInto(out *KongConfig) {
+func (in *MetaConfig) DeepCopyInto(out *MetaConfig) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ExtraEnv != nil {
 		in, out := &in.ExtraEnv, &out.ExtraEnv
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KongConfig.
-func (in *KongConfig) DeepCopy() *KongConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaConfig.
+func (in *MetaConfig) DeepCopy() *MetaConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KongConfig)
+	out := new(MetaConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetaConfig) DeepCopyInto(out *MetaConfig) {
+func (in *OAuthProvider) DeepCopyInto(out *OAuthProvider) {
+	*out = *in
+	in.SecretRef.DeepCopyInto(&out.SecretRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthProvider.
+func (in *OAuthProvider) DeepCopy() *OAuthProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolerConfig) DeepCopyInto(out *PoolerConfig) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RouteComponents != nil {
+		in, out := &in.RouteComponents, &out.RouteComponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ExtraEnv != nil {
 		in, out := &in.ExtraEnv, &out.ExtraEnv
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetaConfig.
-func (in *MetaConfig) DeepCopy() *MetaConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolerConfig.
+func (in *PoolerConfig) DeepCopy() *PoolerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(MetaConfig)
+	out := new(PoolerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -248,12 +1042,22 @@ func (in *PostgRESTConfig) DeepCopyInto(out *PostgRESTConfig) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExposedSchemas != nil {
+		in, out := &in.ExposedSchemas, &out.ExposedSchemas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraSearchPath != nil {
+		in, out := &in.ExtraSearchPath, &out.ExtraSearchPath
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ExtraEnv != nil {
 		in, out := &in.ExtraEnv, &out.ExtraEnv
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -275,12 +1079,12 @@ func (in *RealtimeConfig) DeepCopyInto(out *RealtimeConfig) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ExtraEnv != nil {
 		in, out := &in.ExtraEnv, &out.ExtraEnv
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -297,17 +1101,63 @@ func (in *RealtimeConfig) DeepCopy() *RealtimeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SAMLConfig) DeepCopyInto(out *SAMLConfig) {
+	*out = *in
+	if in.PrivateKeySecretRef != nil {
+		in, out := &in.PrivateKeySecretRef, &out.PrivateKeySecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SAMLConfig.
+func (in *SAMLConfig) DeepCopy() *SAMLConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SAMLConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SMSConfig) DeepCopyInto(out *SMSConfig) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SMSConfig.
+func (in *SMSConfig) DeepCopy() *SMSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SMSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageAPIConfig) DeepCopyInto(out *StorageAPIConfig) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TUS != nil {
+		in, out := &in.TUS, &out.TUS
+		*out = new(StorageTUSConfig)
+		**out = **in
+	}
+	if in.S3ProtocolSecretRef != nil {
+		in, out := &in.S3ProtocolSecretRef, &out.S3ProtocolSecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
 	if in.ExtraEnv != nil {
 		in, out := &in.ExtraEnv, &out.ExtraEnv
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -328,6 +1178,16 @@ func (in *StorageAPIConfig) DeepCopy() *StorageAPIConfig {
 func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
 	*out = *in
 	out.SecretRef = in.SecretRef
+	if in.ForcePathStyle != nil {
+		in, out := &in.ForcePathStyle, &out.ForcePathStyle
+		*out = new(bool)
+		**out = **in
+	}
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(StorageFileConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
@@ -340,24 +1200,64 @@ func (in *StorageConfig) DeepCopy() *StorageConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageFileConfig) DeepCopyInto(out *StorageFileConfig) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageFileConfig.
+func (in *StorageFileConfig) DeepCopy() *StorageFileConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageFileConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageTUSConfig) DeepCopyInto(out *StorageTUSConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageTUSConfig.
+func (in *StorageTUSConfig) DeepCopy() *StorageTUSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageTUSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StudioConfig) DeepCopyInto(out *StudioConfig) {
 	*out = *in
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.ExtraEnv != nil {
 		in, out := &in.ExtraEnv, &out.ExtraEnv
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.DashboardBasicAuthSecretRef != nil {
 		in, out := &in.DashboardBasicAuthSecretRef, &out.DashboardBasicAuthSecretRef
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 }
@@ -372,6 +1272,118 @@ func (in *StudioConfig) DeepCopy() *StudioConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupabaseAPIKey) DeepCopyInto(out *SupabaseAPIKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupabaseAPIKey.
+func (in *SupabaseAPIKey) DeepCopy() *SupabaseAPIKey {
+	if in == nil {
+		return nil
+	}
+	out := new(SupabaseAPIKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SupabaseAPIKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupabaseAPIKeyList) DeepCopyInto(out *SupabaseAPIKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SupabaseAPIKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupabaseAPIKeyList.
+func (in *SupabaseAPIKeyList) DeepCopy() *SupabaseAPIKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SupabaseAPIKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SupabaseAPIKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupabaseAPIKeySpec) DeepCopyInto(out *SupabaseAPIKeySpec) {
+	*out = *in
+	out.ProjectRef = in.ProjectRef
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupabaseAPIKeySpec.
+func (in *SupabaseAPIKeySpec) DeepCopy() *SupabaseAPIKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SupabaseAPIKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SupabaseAPIKeyStatus) DeepCopyInto(out *SupabaseAPIKeyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupabaseAPIKeyStatus.
+func (in *SupabaseAPIKeyStatus) DeepCopy() *SupabaseAPIKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SupabaseAPIKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SupabaseProject) DeepCopyInto(out *SupabaseProject) {
 	*out = *in
@@ -435,7 +1447,12 @@ func (in *SupabaseProjectList) DeepCopyObject() runtime.Object {
 func (in *SupabaseProjectSpec) DeepCopyInto(out *SupabaseProjectSpec) {
 	*out = *in
 	out.Database = in.Database
-	out.Storage = in.Storage
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Kong != nil {
 		in, out := &in.Kong, &out.Kong
 		*out = new(KongConfig)
@@ -461,6 +1478,31 @@ func (in *SupabaseProjectSpec) DeepCopyInto(out *SupabaseProjectSpec) {
 		*out = new(StorageAPIConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Imgproxy != nil {
+		in, out := &in.Imgproxy, &out.Imgproxy
+		*out = new(ImgproxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = new(FunctionsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pooler != nil {
+		in, out := &in.Pooler, &out.Pooler
+		*out = new(PoolerConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Analytics != nil {
+		in, out := &in.Analytics, &out.Analytics
+		*out = new(AnalyticsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vector != nil {
+		in, out := &in.Vector, &out.Vector
+		*out = new(VectorConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Meta != nil {
 		in, out := &in.Meta, &out.Meta
 		*out = new(MetaConfig)
@@ -476,6 +1518,11 @@ func (in *SupabaseProjectSpec) DeepCopyInto(out *SupabaseProjectSpec) {
 		*out = new(IngressConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SupabaseProjectSpec.
@@ -493,7 +1540,7 @@ func (in *SupabaseProjectStatus) DeepCopyInto(out *SupabaseProjectStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -501,6 +1548,7 @@ func (in *SupabaseProjectStatus) DeepCopyInto(out *SupabaseProjectStatus) {
 	in.Components.DeepCopyInto(&out.Components)
 	in.Dependencies.DeepCopyInto(&out.Dependencies)
 	out.Endpoints = in.Endpoints
+	in.APIKeys.DeepCopyInto(&out.APIKeys)
 	if in.LastReconcileTime != nil {
 		in, out := &in.LastReconcileTime, &out.LastReconcileTime
 		*out = (*in).DeepCopy()
@@ -516,3 +1564,37 @@ func (in *SupabaseProjectStatus) DeepCopy() *SupabaseProjectStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VectorConfig) DeepCopyInto(out *VectorConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VectorConfig.
+func (in *VectorConfig) DeepCopy() *VectorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VectorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
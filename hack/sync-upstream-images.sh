@@ -28,6 +28,11 @@ MAPPINGS=(
     "rest DefaultPostgRESTImage PostgRESTConfig"
     "realtime DefaultRealtimeImage RealtimeConfig"
     "storage DefaultStorageAPIImage StorageAPIConfig"
+    "imgproxy DefaultImgproxyImage ImgproxyConfig"
+    "functions DefaultFunctionsImage FunctionsConfig"
+    "supavisor DefaultPoolerImage PoolerConfig"
+    "analytics DefaultAnalyticsImage AnalyticsConfig"
+    "vector DefaultVectorImage VectorConfig"
     "meta DefaultMetaImage MetaConfig"
     "studio DefaultStudioImage StudioConfig"
 )
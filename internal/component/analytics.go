@@ -0,0 +1,200 @@
+package component
+
+import (
+	"strconv"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// analyticsPort is the port Logflare listens on, matching the upstream compose file.
+const analyticsPort = 4000
+
+// Keys of the generated <project>-analytics Secret.
+const (
+	AnalyticsPublicAccessToken  = "public-access-token"
+	AnalyticsPrivateAccessToken = "private-access-token"
+)
+
+// AnalyticsEnabled reports whether the project asks for Logflare.
+func AnalyticsEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Analytics != nil && project.Spec.Analytics.Enabled
+}
+
+// AnalyticsURL returns the in-cluster URL of the Logflare API.
+func AnalyticsURL(project *v1alpha1.SupabaseProject) string {
+	return "http://" + project.Name + "-analytics:" + strconv.Itoa(analyticsPort)
+}
+
+type AnalyticsBuilder struct{}
+
+var _ ComponentBuilder = (*AnalyticsBuilder)(nil)
+
+func (b *AnalyticsBuilder) Name() string {
+	return "analytics"
+}
+
+func (b *AnalyticsBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv1.Deployment, error) {
+	replicas := int32(1)
+	if project.Spec.Analytics != nil && project.Spec.Analytics.Replicas > 0 {
+		replicas = project.Spec.Analytics.Replicas
+	}
+
+	image := v1alpha1.DefaultAnalyticsImage
+	if project.Spec.Analytics != nil && project.Spec.Analytics.Image != "" {
+		image = project.Spec.Analytics.Image
+	}
+
+	resources := getAnalyticsDefaultResources()
+	if project.Spec.Analytics != nil && project.Spec.Analytics.Resources != nil {
+		resources = *project.Spec.Analytics.Resources
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "analytics",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "analytics",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	dbSecretName := project.Spec.Database.SecretRef.Name
+	analyticsSecretName := project.Name + "-analytics"
+
+	env := []corev1.EnvVar{
+		secretKeyEnvVar("DB_HOSTNAME", dbSecretName, "host"),
+		secretKeyEnvVar("DB_PORT", dbSecretName, "port"),
+		secretKeyEnvVar("DB_USERNAME", dbSecretName, "username"),
+		secretKeyEnvVar("DB_PASSWORD", dbSecretName, "password"),
+		{
+			Name:  "DB_DATABASE",
+			Value: "_supabase",
+		},
+		{
+			Name:  "DB_SCHEMA",
+			Value: "_analytics",
+		},
+		{
+			Name:  "POSTGRES_BACKEND_URL",
+			Value: "postgresql://$(DB_USERNAME):$(DB_PASSWORD)@$(DB_HOSTNAME):$(DB_PORT)/_supabase",
+		},
+		{
+			Name:  "POSTGRES_BACKEND_SCHEMA",
+			Value: "_analytics",
+		},
+		secretKeyEnvVar("LOGFLARE_PUBLIC_ACCESS_TOKEN", analyticsSecretName, AnalyticsPublicAccessToken),
+		secretKeyEnvVar("LOGFLARE_PRIVATE_ACCESS_TOKEN", analyticsSecretName, AnalyticsPrivateAccessToken),
+		{
+			Name:  "LOGFLARE_NODE_HOST",
+			Value: "127.0.0.1",
+		},
+		{
+			Name:  "LOGFLARE_SINGLE_TENANT",
+			Value: "true",
+		},
+		{
+			Name:  "LOGFLARE_SUPABASE_MODE",
+			Value: "true",
+		},
+		{
+			Name:  "LOGFLARE_MIN_CLUSTER_SIZE",
+			Value: "1",
+		},
+		{
+			Name:  "LOGFLARE_FEATURE_FLAG_OVERRIDE",
+			Value: "multibackend=true",
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-analytics",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "analytics",
+							Image:     image,
+							Resources: resources,
+							Env:       env,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: analyticsPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if project.Spec.Analytics != nil && len(project.Spec.Analytics.ExtraEnv) > 0 {
+		deployment.Spec.Template.Spec.Containers[0].Env = append(
+			deployment.Spec.Template.Spec.Containers[0].Env,
+			project.Spec.Analytics.ExtraEnv...,
+		)
+	}
+
+	return deployment, nil
+}
+
+func (b *AnalyticsBuilder) BuildService(project *v1alpha1.SupabaseProject) (*corev1.Service, error) {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "analytics",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "analytics",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-analytics",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       analyticsPort,
+					TargetPort: intstr.FromInt(analyticsPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}, nil
+}
+
+func getAnalyticsDefaultResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+			corev1.ResourceCPU:    resource.MustParse("250m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+			corev1.ResourceCPU:    resource.MustParse("1"),
+		},
+	}
+}
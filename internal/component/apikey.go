@@ -0,0 +1,112 @@
+package component
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// APIKeySecretKey is the key of a SupabaseAPIKey's Secret holding the token.
+const APIKeySecretKey = "api-key"
+
+// KongAPIKeysChecksumAnnotation carries a digest of the SupabaseAPIKeys Kong
+// serves, so its pods roll when a key is signed again.
+const KongAPIKeysChecksumAnnotation = "supabase.strrl.dev/api-keys-checksum"
+
+// KongAPIKey is a ready SupabaseAPIKey that Kong registers as a consumer.
+type KongAPIKey struct {
+	// Name is the name of the SupabaseAPIKey.
+	Name string
+	// Group is the ACL group of the consumer.
+	Group string
+	// SecretName is the Secret holding the token under APIKeySecretKey.
+	SecretName string
+	// KeyID is the jti of the current token.
+	KeyID string
+}
+
+// kongAPIKeyConsumer returns the Kong consumer name of a SupabaseAPIKey. The
+// prefix keeps it apart from the built-in anon, service_role and DASHBOARD
+// consumers.
+func kongAPIKeyConsumer(name string) string {
+	return "apikey-" + name
+}
+
+// sortedKongAPIKeys orders the keys by name, so the consumers and the
+// environment variables carrying their tokens line up between the ConfigMap
+// and the Deployment.
+func sortedKongAPIKeys(keys []KongAPIKey) []KongAPIKey {
+	sorted := slices.Clone(keys)
+	slices.SortFunc(sorted, func(a, b KongAPIKey) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sorted
+}
+
+// kongAPIKeyEnv is the environment variable the entrypoint reads the token of
+// the i-th sorted SupabaseAPIKey from.
+func kongAPIKeyEnv(i int) string {
+	return fmt.Sprintf("SUPABASE_API_KEY_%d", i)
+}
+
+// kongAPIKeysChecksum digests the names and key IDs of keys, or returns ""
+// when there are none.
+func kongAPIKeysChecksum(keys []KongAPIKey) string {
+	if len(keys) == 0 {
+		return ""
+	}
+	hash := sha256.New()
+	for _, key := range sortedKongAPIKeys(keys) {
+		fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", key.Name, key.Group, key.KeyID)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// addKongAPIKeyConsumers registers keys as consumers with key-auth
+// credentials and ACL groups in the declarative config.
+func addKongAPIKeyConsumers(config string, keys []KongAPIKey) string {
+	if len(keys) == 0 {
+		return config
+	}
+
+	var consumers, acls strings.Builder
+	for i, key := range sortedKongAPIKeys(keys) {
+		consumer := kongAPIKeyConsumer(key.Name)
+		fmt.Fprintf(&consumers, "\n  - username: %s\n    keyauth_credentials:\n      - key: $%s", consumer, kongAPIKeyEnv(i))
+		fmt.Fprintf(&acls, "\n  - consumer: %s\n    group: %s", consumer, key.Group)
+	}
+
+	config = strings.Replace(config, "\n\nacls:", consumers.String()+"\n\nacls:", 1)
+	return strings.Replace(config, "\n\nbasicauth_credentials:", acls.String()+"\n\nbasicauth_credentials:", 1)
+}
+
+// kongAPIKeyRevocationPlugin returns the global pre-function that rejects the
+// token of a SupabaseAPIKey sent in the Authorization header once the key is
+// deleted, expired or signed again. key-auth only checks the apikey header and
+// the Authorization header is passed on as is, so without it a revoked token
+// would keep working next to the anon key. Tokens are matched by the iss and
+// jti claims SignNamedAPIKey sets; the anon and service role keys and user
+// tokens carry no jti.
+func kongAPIKeyRevocationPlugin(keys []KongAPIKey) v1alpha1.KongPlugin {
+	var active strings.Builder
+	for _, key := range sortedKongAPIKeys(keys) {
+		fmt.Fprintf(&active, " [%s] = true,", kongQuote(key.KeyID))
+	}
+
+	access := "local active = {" + active.String() + ` }
+local payload = (kong.request.get_header("authorization") or ""):match("^Bearer%s+[%w_-]+%.([%w_-]+)%.")
+local claims = payload and ngx.decode_base64((payload:gsub("%-", "+"):gsub("_", "/")))
+local jti = claims and claims:find('"iss":"supabase"', 1, true) and claims:match('"jti":"(%x+)"')
+if jti and not active[jti] then
+  return kong.response.exit(401, { message = "Invalid API key" })
+end
+`
+	config, _ := json.Marshal(map[string][]string{"access": {access}})
+	return v1alpha1.KongPlugin{Name: "pre-function", Config: &runtime.RawExtension{Raw: config}}
+}
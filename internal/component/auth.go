@@ -1,6 +1,11 @@
 package component
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
 	"github.com/strrl/supabase-operator/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -9,7 +14,40 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-type AuthBuilder struct{}
+// SAMLKeyChecksumAnnotation carries a digest of the SAML private key, so the
+// Auth pods roll when the key is regenerated or the referenced Secret changes.
+const SAMLKeyChecksumAnnotation = "supabase.strrl.dev/saml-key-checksum"
+
+// AuthBuilder builds GoTrue. JWTChecksum and SAMLKeyChecksum are stamped on the
+// pod template so that GoTrue restarts with a rotated JWT secret or SAML key.
+type AuthBuilder struct {
+	JWTChecksum     string
+	SAMLKeyChecksum string
+}
+
+// SAMLPrivateKeyRef returns the Secret key holding the SAML private key: the
+// referenced one, or the generated <project>-saml. ok is false unless SAML is
+// enabled.
+func SAMLPrivateKeyRef(project *v1alpha1.SupabaseProject) (ref corev1.SecretKeySelector, ok bool) {
+	if project.Spec.Auth == nil || project.Spec.Auth.SAML == nil || !project.Spec.Auth.SAML.Enabled {
+		return corev1.SecretKeySelector{}, false
+	}
+	if project.Spec.Auth.SAML.PrivateKeySecretRef != nil {
+		return *project.Spec.Auth.SAML.PrivateKeySecretRef, true
+	}
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{
+			Name: project.Name + "-saml",
+		},
+		Key: "saml-private-key",
+	}, true
+}
+
+// SAMLKeyChecksum digests a SAML private key.
+func SAMLKeyChecksum(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:])
+}
 
 var _ ComponentBuilder = (*AuthBuilder)(nil)
 
@@ -47,14 +85,48 @@ func (b *AuthBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		sslMode = defaultSSLMode
 	}
 
+	externalURL := "http://localhost:8000"
+	if ingressURL := IngressURL(project); ingressURL != "" {
+		externalURL = ingressURL
+	}
+
+	// Without SMTP there is no way to deliver confirmation emails, so users
+	// are confirmed on signup.
+	var smtpSecretRef *corev1.SecretReference
+	if project.Spec.Auth != nil {
+		smtpSecretRef = project.Spec.Auth.SMTPSecretRef
+	}
+	mailerAutoconfirm := smtpSecretRef == nil
+
+	siteURL := externalURL
+	jwtExpiry := int32(3600)
+	disableSignup := false
+	if auth := project.Spec.Auth; auth != nil {
+		if auth.SiteURL != "" {
+			siteURL = auth.SiteURL
+		}
+		if auth.JWTExpirySeconds > 0 {
+			jwtExpiry = auth.JWTExpirySeconds
+		}
+		disableSignup = auth.DisableSignup
+		if auth.EmailAutoconfirm != nil {
+			mailerAutoconfirm = *auth.EmailAutoconfirm
+		}
+	}
+
+	dbURL := "postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)?sslmode=$(DB_SSL_MODE)"
+	if poolerRoutes(project, "auth") {
+		dbURL = poolerDatabaseURL(project, "$(DB_USER)", poolerSessionPort)
+	}
+
 	env := []corev1.EnvVar{
 		{
 			Name:  "API_EXTERNAL_URL",
-			Value: "http://localhost:8000",
+			Value: externalURL,
 		},
 		{
 			Name:  "GOTRUE_SITE_URL",
-			Value: "http://localhost:8000",
+			Value: siteURL,
 		},
 		{
 			Name:  "GOTRUE_API_HOST",
@@ -129,7 +201,7 @@ func (b *AuthBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		},
 		{
 			Name:  "GOTRUE_DB_DATABASE_URL",
-			Value: "postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)?sslmode=$(DB_SSL_MODE)",
+			Value: dbURL,
 		},
 		{
 			Name: "GOTRUE_JWT_SECRET",
@@ -144,7 +216,7 @@ func (b *AuthBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		},
 		{
 			Name:  "GOTRUE_JWT_EXP",
-			Value: "3600",
+			Value: strconv.Itoa(int(jwtExpiry)),
 		},
 		{
 			Name:  "GOTRUE_JWT_ADMIN_ROLES",
@@ -160,7 +232,7 @@ func (b *AuthBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		},
 		{
 			Name:  "GOTRUE_DISABLE_SIGNUP",
-			Value: "false",
+			Value: strconv.FormatBool(disableSignup),
 		},
 		{
 			Name:  "GOTRUE_EXTERNAL_EMAIL_ENABLED",
@@ -168,10 +240,82 @@ func (b *AuthBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		},
 		{
 			Name:  "GOTRUE_MAILER_AUTOCONFIRM",
-			Value: "true",
+			Value: strconv.FormatBool(mailerAutoconfirm),
 		},
 	}
 
+	if smtpSecretRef != nil {
+		optional := true
+		env = append(env,
+			secretKeyEnvVar("GOTRUE_SMTP_HOST", smtpSecretRef.Name, "host"),
+			secretKeyEnvVar("GOTRUE_SMTP_PORT", smtpSecretRef.Name, "port"),
+			secretKeyEnvVar("GOTRUE_SMTP_USER", smtpSecretRef.Name, "username"),
+			secretKeyEnvVar("GOTRUE_SMTP_PASS", smtpSecretRef.Name, "password"),
+			secretKeyEnvVar("GOTRUE_SMTP_ADMIN_EMAIL", smtpSecretRef.Name, "from"),
+			corev1.EnvVar{
+				Name: "GOTRUE_SMTP_SENDER_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: smtpSecretRef.Name,
+						},
+						Key:      "senderName",
+						Optional: &optional,
+					},
+				},
+			},
+			// Links in emails point at the Auth routes exposed through Kong.
+			corev1.EnvVar{Name: "GOTRUE_MAILER_URLPATHS_INVITE", Value: "/auth/v1/verify"},
+			corev1.EnvVar{Name: "GOTRUE_MAILER_URLPATHS_CONFIRMATION", Value: "/auth/v1/verify"},
+			corev1.EnvVar{Name: "GOTRUE_MAILER_URLPATHS_RECOVERY", Value: "/auth/v1/verify"},
+			corev1.EnvVar{Name: "GOTRUE_MAILER_URLPATHS_EMAIL_CHANGE", Value: "/auth/v1/verify"},
+		)
+	}
+
+	if AsymmetricJWTEnabled(project) {
+		// GoTrue then signs access tokens with the asymmetric key and still
+		// accepts the HS256 anon and service role keys.
+		env = append(env,
+			secretKeyEnvVar("GOTRUE_JWT_KEYS", project.Name+"-jwt", JWTAuthKeysKey),
+			corev1.EnvVar{Name: "GOTRUE_JWT_VALID_METHODS", Value: "HS256,RS256,ES256"},
+		)
+	}
+
+	if project.Spec.Auth != nil {
+		env = append(env, authSettingsEnv(project.Spec.Auth)...)
+	}
+
+	if privateKeyRef, ok := SAMLPrivateKeyRef(project); ok {
+		env = append(env,
+			corev1.EnvVar{Name: "GOTRUE_SAML_ENABLED", Value: "true"},
+			secretKeyEnvVar("GOTRUE_SAML_PRIVATE_KEY", privateKeyRef.Name, privateKeyRef.Key),
+		)
+	}
+
+	if project.Spec.Auth != nil && project.Spec.Auth.SMS != nil {
+		env = append(env, smsEnv(project.Spec.Auth.SMS)...)
+	}
+
+	if project.Spec.Auth != nil {
+		for _, hook := range project.Spec.Auth.Hooks {
+			env = append(env, authHookEnv(hook)...)
+		}
+	}
+
+	if project.Spec.Auth != nil {
+		for _, provider := range project.Spec.Auth.Providers {
+			env = append(env, oauthProviderEnv(provider, externalURL)...)
+		}
+	}
+
+	annotations := jwtPodAnnotations(b.JWTChecksum)
+	if b.SAMLKeyChecksum != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[SAMLKeyChecksumAnnotation] = b.SAMLKeyChecksum
+	}
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      project.Name + "-auth",
@@ -185,7 +329,8 @@ func (b *AuthBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -248,6 +393,217 @@ func (b *AuthBuilder) BuildService(project *v1alpha1.SupabaseProject) (*corev1.S
 	}, nil
 }
 
+// authSettingsEnv renders the optional GoTrue behaviour settings. Only fields
+// that are set are rendered so GoTrue keeps its own defaults otherwise.
+func authSettingsEnv(auth *v1alpha1.AuthConfig) []corev1.EnvVar {
+	var env []corev1.EnvVar
+
+	if len(auth.AdditionalRedirectURLs) > 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "GOTRUE_URI_ALLOW_LIST",
+			Value: strings.Join(auth.AdditionalRedirectURLs, ","),
+		})
+	}
+	if auth.PhoneAutoconfirm {
+		env = append(env, corev1.EnvVar{Name: "GOTRUE_SMS_AUTOCONFIRM", Value: "true"})
+	}
+	if auth.AnonymousSignIns {
+		env = append(env, corev1.EnvVar{Name: "GOTRUE_EXTERNAL_ANONYMOUS_USERS_ENABLED", Value: "true"})
+	}
+	if auth.PasswordMinLength > 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "GOTRUE_PASSWORD_MIN_LENGTH",
+			Value: strconv.Itoa(int(auth.PasswordMinLength)),
+		})
+	}
+
+	if limits := auth.RateLimits; limits != nil {
+		for _, limit := range []struct {
+			name  string
+			value int32
+		}{
+			{"GOTRUE_RATE_LIMIT_EMAIL_SENT", limits.EmailSent},
+			{"GOTRUE_RATE_LIMIT_SMS_SENT", limits.SMSSent},
+			{"GOTRUE_RATE_LIMIT_VERIFY", limits.Verify},
+			{"GOTRUE_RATE_LIMIT_TOKEN_REFRESH", limits.TokenRefresh},
+			{"GOTRUE_RATE_LIMIT_OTP", limits.OTP},
+			{"GOTRUE_RATE_LIMIT_ANONYMOUS_USERS", limits.AnonymousUsers},
+		} {
+			if limit.value > 0 {
+				env = append(env, corev1.EnvVar{Name: limit.name, Value: strconv.Itoa(int(limit.value))})
+			}
+		}
+	}
+
+	if mfa := auth.MFA; mfa != nil {
+		if mfa.TOTP != nil {
+			enabled := strconv.FormatBool(*mfa.TOTP)
+			env = append(env,
+				corev1.EnvVar{Name: "GOTRUE_MFA_TOTP_ENROLL_ENABLED", Value: enabled},
+				corev1.EnvVar{Name: "GOTRUE_MFA_TOTP_VERIFY_ENABLED", Value: enabled},
+			)
+		}
+		if mfa.Phone != nil {
+			enabled := strconv.FormatBool(*mfa.Phone)
+			env = append(env,
+				corev1.EnvVar{Name: "GOTRUE_MFA_PHONE_ENROLL_ENABLED", Value: enabled},
+				corev1.EnvVar{Name: "GOTRUE_MFA_PHONE_VERIFY_ENABLED", Value: enabled},
+			)
+		}
+		if mfa.MaxEnrolledFactors > 0 {
+			env = append(env, corev1.EnvVar{
+				Name:  "GOTRUE_MFA_MAX_ENROLLED_FACTORS",
+				Value: strconv.Itoa(int(mfa.MaxEnrolledFactors)),
+			})
+		}
+	}
+
+	return env
+}
+
+// authHookEnv renders the GOTRUE_HOOK_<TYPE>_* variables of a hook.
+func authHookEnv(hook v1alpha1.AuthHook) []corev1.EnvVar {
+	prefix := "GOTRUE_HOOK_" + strings.ToUpper(hook.Type) + "_"
+
+	env := []corev1.EnvVar{
+		{
+			Name:  prefix + "ENABLED",
+			Value: "true",
+		},
+		{
+			Name:  prefix + "URI",
+			Value: hook.URI,
+		},
+	}
+
+	if hook.SecretRef != nil {
+		env = append(env, secretKeyEnvVar(prefix+"SECRETS", hook.SecretRef.Name, hook.SecretRef.Key))
+	}
+
+	return env
+}
+
+// smsProviderSecretEnv maps the GOTRUE_SMS_<PROVIDER>_* variables of each SMS
+// provider to the keys of its credentials Secret.
+var smsProviderSecretEnv = map[string][][2]string{
+	"twilio": {
+		{"GOTRUE_SMS_TWILIO_ACCOUNT_SID", "accountSid"},
+		{"GOTRUE_SMS_TWILIO_AUTH_TOKEN", "authToken"},
+		{"GOTRUE_SMS_TWILIO_MESSAGE_SERVICE_SID", "messageServiceSid"},
+	},
+	"messagebird": {
+		{"GOTRUE_SMS_MESSAGEBIRD_ACCESS_KEY", "accessKey"},
+		{"GOTRUE_SMS_MESSAGEBIRD_ORIGINATOR", "originator"},
+	},
+	"textlocal": {
+		{"GOTRUE_SMS_TEXTLOCAL_API_KEY", "apiKey"},
+		{"GOTRUE_SMS_TEXTLOCAL_SENDER", "sender"},
+	},
+	"vonage": {
+		{"GOTRUE_SMS_VONAGE_API_KEY", "apiKey"},
+		{"GOTRUE_SMS_VONAGE_API_SECRET", "apiSecret"},
+		{"GOTRUE_SMS_VONAGE_FROM", "from"},
+	},
+}
+
+// smsEnv renders the phone provider and GOTRUE_SMS_* variables.
+func smsEnv(sms *v1alpha1.SMSConfig) []corev1.EnvVar {
+	otpLength := int32(6)
+	if sms.OTPLength > 0 {
+		otpLength = sms.OTPLength
+	}
+
+	otpExpiry := int32(60)
+	if sms.OTPExpirySeconds > 0 {
+		otpExpiry = sms.OTPExpirySeconds
+	}
+
+	template := "Your code is {{ .Code }}"
+	if sms.Template != "" {
+		template = sms.Template
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "GOTRUE_EXTERNAL_PHONE_ENABLED",
+			Value: "true",
+		},
+		{
+			Name:  "GOTRUE_SMS_PROVIDER",
+			Value: sms.Provider,
+		},
+		{
+			Name:  "GOTRUE_SMS_OTP_LENGTH",
+			Value: strconv.Itoa(int(otpLength)),
+		},
+		{
+			Name:  "GOTRUE_SMS_OTP_EXP",
+			Value: strconv.Itoa(int(otpExpiry)),
+		},
+		{
+			Name:  "GOTRUE_SMS_TEMPLATE",
+			Value: template,
+		},
+	}
+
+	for _, mapping := range smsProviderSecretEnv[sms.Provider] {
+		env = append(env, secretKeyEnvVar(mapping[0], sms.SecretRef.Name, mapping[1]))
+	}
+
+	return env
+}
+
+// oauthProviderEnv renders the GOTRUE_EXTERNAL_<NAME>_* variables of a provider.
+func oauthProviderEnv(provider v1alpha1.OAuthProvider, externalURL string) []corev1.EnvVar {
+	prefix := "GOTRUE_EXTERNAL_" + strings.ToUpper(provider.Name) + "_"
+
+	redirectURI := provider.RedirectURI
+	if redirectURI == "" {
+		redirectURI = externalURL + "/auth/v1/callback"
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  prefix + "ENABLED",
+			Value: strconv.FormatBool(provider.Enabled),
+		},
+		{
+			Name:  prefix + "CLIENT_ID",
+			Value: provider.ClientID,
+		},
+		secretKeyEnvVar(prefix+"SECRET", provider.SecretRef.Name, provider.SecretRef.Key),
+		{
+			Name:  prefix + "REDIRECT_URI",
+			Value: redirectURI,
+		},
+	}
+
+	if provider.URL != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  prefix + "URL",
+			Value: provider.URL,
+		})
+	}
+
+	return env
+}
+
+// secretKeyEnvVar returns an env var sourced from a key of a Secret in the
+// project namespace.
+func secretKeyEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+}
+
 func getAuthDefaultResources() corev1.ResourceRequirements {
 	return corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
@@ -1,6 +1,17 @@
 package component
 
+import "github.com/strrl/supabase-operator/api/v1alpha1"
+
 const (
 	// defaultSSLMode is the default SSL mode for database connections
 	defaultSSLMode = "require"
 )
+
+// DatabaseSSLMode returns spec.database.sslMode, or defaultSSLMode when it is
+// not set.
+func DatabaseSSLMode(project *v1alpha1.SupabaseProject) string {
+	if project.Spec.Database.SSLMode != "" {
+		return project.Spec.Database.SSLMode
+	}
+	return defaultSSLMode
+}
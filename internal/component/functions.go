@@ -0,0 +1,468 @@
+package component
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// functionsPort is the port the edge runtime listens on.
+	functionsPort = 9000
+
+	// functionsRootPath is where user functions live, one directory each. The
+	// main service is mounted outside of it so a function named "main" cannot
+	// shadow the router.
+	functionsRootPath = "/home/deno/functions"
+	functionsMainPath = "/home/deno/main"
+
+	// FunctionsChecksumAnnotation carries a digest of the function code loaded
+	// from ConfigMaps, so the pods roll when any of it changes.
+	FunctionsChecksumAnnotation = "supabase.strrl.dev/functions-checksum"
+)
+
+// functionsMainScript mirrors upstream supabase docker/volumes/functions/main/index.ts.
+// JWT verification is decided per function from FUNCTIONS_VERIFY_JWT, falling
+// back to VERIFY_JWT for functions that are not listed. Tokens are verified
+// against the keys of JWT_JWKS rather than the raw JWT_SECRET text: the
+// operator signs API keys with the decoded secret, and the key set also holds
+// the previous secret during a rotation and Auth's asymmetric signing key.
+// jose's createLocalJWKSet does not accept symmetric keys, so every key is
+// imported and tried in turn.
+const functionsMainScript = `import * as jose from 'https://deno.land/x/jose@v4.14.4/index.ts'
+
+console.log('main function started')
+
+const JWT_KEYS: Promise<(jose.KeyLike | Uint8Array)[]> = Promise.all(
+  (JSON.parse(Deno.env.get('JWT_JWKS') ?? '{"keys":[]}').keys as jose.JWK[]).map((jwk) =>
+    jose.importJWK(jwk, jwk.alg ?? (jwk.kty === 'oct' ? 'HS256' : undefined)),
+  ),
+)
+const DEFAULT_VERIFY_JWT = Deno.env.get('VERIFY_JWT') === 'true'
+const VERIFY_JWT: Record<string, boolean> = JSON.parse(Deno.env.get('FUNCTIONS_VERIFY_JWT') ?? '{}')
+
+function getAuthToken(req: Request) {
+  const authHeader = req.headers.get('authorization')
+  if (!authHeader) {
+    throw new Error('Missing authorization header')
+  }
+  const [bearer, token] = authHeader.split(' ')
+  if (bearer !== 'Bearer') {
+    throw new Error("Auth header is not 'Bearer {token}'")
+  }
+  return token
+}
+
+async function verifyJWT(jwt: string): Promise<boolean> {
+  let lastError: unknown = new Error('no keys in JWT_JWKS')
+  for (const key of await JWT_KEYS) {
+    try {
+      await jose.jwtVerify(jwt, key)
+      return true
+    } catch (err) {
+      lastError = err
+    }
+  }
+  console.error(lastError)
+  return false
+}
+
+function jsonResponse(body: unknown, status: number) {
+  return new Response(JSON.stringify(body), {
+    status,
+    headers: { 'Content-Type': 'application/json' },
+  })
+}
+
+Deno.serve(async (req: Request) => {
+  const serviceName = new URL(req.url).pathname.split('/')[1]
+  if (!serviceName) {
+    return jsonResponse({ msg: 'missing function name in request' }, 400)
+  }
+
+  const verify = VERIFY_JWT[serviceName] ?? DEFAULT_VERIFY_JWT
+  if (req.method !== 'OPTIONS' && verify) {
+    try {
+      if (!(await verifyJWT(getAuthToken(req)))) {
+        return jsonResponse({ msg: 'Invalid JWT' }, 401)
+      }
+    } catch (e) {
+      console.error(e)
+      return jsonResponse({ msg: e.toString() }, 401)
+    }
+  }
+
+  const servicePath = '` + functionsRootPath + `/' + serviceName
+  console.error('serving the request with ' + servicePath)
+
+  const envVarsObj = Deno.env.toObject()
+  const envVars = Object.keys(envVarsObj).map((k) => [k, envVarsObj[k]])
+
+  try {
+    const worker = await EdgeRuntime.userWorkers.create({
+      servicePath,
+      memoryLimitMb: 150,
+      workerTimeoutMs: 60 * 1000,
+      noModuleCache: false,
+      importMapPath: null,
+      envVars,
+    })
+    return await worker.fetch(req)
+  } catch (e) {
+    return jsonResponse({ msg: e.toString() }, 500)
+  }
+})
+`
+
+// FunctionsEnabled reports whether the project asks for the edge runtime.
+func FunctionsEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Functions != nil && project.Spec.Functions.Enabled
+}
+
+// FunctionsConfigMapNames returns the ConfigMaps holding function code, in
+// spec order.
+func FunctionsConfigMapNames(project *v1alpha1.SupabaseProject) []string {
+	if project.Spec.Functions == nil {
+		return nil
+	}
+
+	var names []string
+	for _, fn := range project.Spec.Functions.Functions {
+		if fn.ConfigMapRef != nil && !slices.Contains(names, fn.ConfigMapRef.Name) {
+			names = append(names, fn.ConfigMapRef.Name)
+		}
+	}
+	return names
+}
+
+// FunctionsChecksum digests the content of the function ConfigMaps. The result
+// only depends on names, keys and values, not on list or map order.
+func FunctionsChecksum(configMaps []corev1.ConfigMap) string {
+	sorted := slices.Clone(configMaps)
+	slices.SortFunc(sorted, func(a, b corev1.ConfigMap) int {
+		if a.Name < b.Name {
+			return -1
+		}
+		if a.Name > b.Name {
+			return 1
+		}
+		return 0
+	})
+
+	hash := sha256.New()
+	for _, cm := range sorted {
+		// encoding/json writes map keys in sorted order.
+		data, _ := json.Marshal(struct {
+			Name       string            `json:"name"`
+			Data       map[string]string `json:"data"`
+			BinaryData map[string][]byte `json:"binaryData"`
+		}{cm.Name, cm.Data, cm.BinaryData})
+		hash.Write(data)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// BuildFunctionsMainConfigMap creates the ConfigMap holding the main service
+// that routes /functions/v1/<name> to user workers.
+func BuildFunctionsMainConfigMap(project *v1alpha1.SupabaseProject) *corev1.ConfigMap {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "functions",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "edge-runtime",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-functions-main",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			"index.ts": functionsMainScript,
+		},
+	}
+}
+
+// EdgeRuntimeBuilder builds the edge functions runtime. CodeChecksum is the
+// FunctionsChecksum of the function ConfigMaps and JWTChecksum the digest of
+// the <project>-jwt Secret; both are stamped on the pod template.
+type EdgeRuntimeBuilder struct {
+	CodeChecksum string
+	JWTChecksum  string
+}
+
+var _ ComponentBuilder = (*EdgeRuntimeBuilder)(nil)
+
+func (b *EdgeRuntimeBuilder) Name() string {
+	return "functions"
+}
+
+func (b *EdgeRuntimeBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv1.Deployment, error) {
+	config := project.Spec.Functions
+	if config == nil {
+		config = &v1alpha1.FunctionsConfig{}
+	}
+
+	replicas := int32(1)
+	if config.Replicas > 0 {
+		replicas = config.Replicas
+	}
+
+	image := v1alpha1.DefaultFunctionsImage
+	if config.Image != "" {
+		image = config.Image
+	}
+
+	resources := getFunctionsDefaultResources()
+	if config.Resources != nil {
+		resources = *config.Resources
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "functions",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "edge-runtime",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	sslMode := project.Spec.Database.SSLMode
+	if sslMode == "" {
+		sslMode = defaultSSLMode
+	}
+
+	verifyJWT := true
+	if config.VerifyJWT != nil {
+		verifyJWT = *config.VerifyJWT
+	}
+	perFunctionVerifyJWT := map[string]bool{}
+	for _, fn := range config.Functions {
+		if fn.VerifyJWT != nil {
+			perFunctionVerifyJWT[fn.Name] = *fn.VerifyJWT
+		}
+	}
+	perFunctionVerifyJWTJSON, err := json.Marshal(perFunctionVerifyJWT)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSecretName := project.Name + "-jwt"
+	dbSecretName := project.Spec.Database.SecretRef.Name
+
+	env := []corev1.EnvVar{
+		secretKeyEnvVar("JWT_SECRET", jwtSecretName, "jwt-secret"),
+		secretKeyEnvVar("JWT_JWKS", jwtSecretName, JWTJWKSKey),
+		secretKeyEnvVar("SUPABASE_ANON_KEY", jwtSecretName, "anon-key"),
+		secretKeyEnvVar("SUPABASE_SERVICE_ROLE_KEY", jwtSecretName, "service-role-key"),
+		{
+			Name:  "SUPABASE_URL",
+			Value: "http://" + project.Name + "-kong:8000",
+		},
+		secretKeyEnvVar("DB_HOST", dbSecretName, "host"),
+		secretKeyEnvVar("DB_PORT", dbSecretName, "port"),
+		secretKeyEnvVar("DB_NAME", dbSecretName, "database"),
+		secretKeyEnvVar("DB_USER", dbSecretName, "username"),
+		secretKeyEnvVar("DB_PASSWORD", dbSecretName, "password"),
+		{
+			Name:  "SUPABASE_DB_URL",
+			Value: "postgresql://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)?sslmode=" + sslMode,
+		},
+		{
+			Name:  "VERIFY_JWT",
+			Value: strconv.FormatBool(verifyJWT),
+		},
+		{
+			Name:  "FUNCTIONS_VERIFY_JWT",
+			Value: string(perFunctionVerifyJWTJSON),
+		},
+	}
+
+	volumes := []corev1.Volume{
+		{
+			Name: "functions-main",
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: project.Name + "-functions-main",
+					},
+				},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "functions-main",
+			MountPath: functionsMainPath,
+			ReadOnly:  true,
+		},
+	}
+
+	switch {
+	case config.Source != nil && config.Source.PersistentVolumeClaim != nil:
+		volumes = append(volumes, corev1.Volume{
+			Name: "functions",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: config.Source.PersistentVolumeClaim.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "functions",
+			MountPath: functionsRootPath,
+			SubPath:   config.Source.PersistentVolumeClaim.SubPath,
+			ReadOnly:  true,
+		})
+	case config.Source != nil && config.Source.Image != nil:
+		volumes = append(volumes, corev1.Volume{
+			Name: "functions",
+			VolumeSource: corev1.VolumeSource{
+				Image: &corev1.ImageVolumeSource{
+					Reference:  config.Source.Image.Reference,
+					PullPolicy: config.Source.Image.PullPolicy,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "functions",
+			MountPath: functionsRootPath,
+			ReadOnly:  true,
+		})
+	default:
+		for _, fn := range config.Functions {
+			if fn.ConfigMapRef == nil {
+				continue
+			}
+			volumeName := "function-" + fn.Name
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: *fn.ConfigMapRef,
+					},
+				},
+			})
+			volumeMounts = append(volumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: functionsRootPath + "/" + fn.Name,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	annotations := jwtPodAnnotations(b.JWTChecksum)
+	if b.CodeChecksum != "" {
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[FunctionsChecksumAnnotation] = b.CodeChecksum
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-functions",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "functions",
+							Image:     image,
+							Resources: resources,
+							Env:       env,
+							Args: []string{
+								"start",
+								"--main-service",
+								functionsMainPath,
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: functionsPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							VolumeMounts: volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+
+	if len(config.ExtraEnv) > 0 {
+		deployment.Spec.Template.Spec.Containers[0].Env = append(
+			deployment.Spec.Template.Spec.Containers[0].Env,
+			config.ExtraEnv...,
+		)
+	}
+
+	return deployment, nil
+}
+
+func (b *EdgeRuntimeBuilder) BuildService(project *v1alpha1.SupabaseProject) (*corev1.Service, error) {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "functions",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "edge-runtime",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-functions",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       functionsPort,
+					TargetPort: intstr.FromInt(functionsPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}, nil
+}
+
+func getFunctionsDefaultResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+			corev1.ResourceCPU:    resource.MustParse("1"),
+		},
+	}
+}
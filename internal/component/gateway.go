@@ -0,0 +1,195 @@
+package component
+
+import (
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// realtimeWebsocketPath is the Kong route prefix of the realtime-v1-ws service.
+	realtimeWebsocketPath = "/realtime/v1/"
+
+	// appProtocolWebsocket and appProtocolWebsocketTLS are the GEP-1911 backend
+	// protocols that tell Gateway implementations the Kong ports carry
+	// websocket upgrades.
+	appProtocolWebsocket    = "kubernetes.io/ws"
+	appProtocolWebsocketTLS = "kubernetes.io/wss"
+)
+
+// GatewayEnabled reports whether the project asks the operator to manage a
+// Gateway API HTTPRoute in front of Kong.
+func GatewayEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Gateway != nil && project.Spec.Gateway.Enabled
+}
+
+// GatewayBackendTLSEnabled reports whether the Gateway should talk TLS to Kong.
+func GatewayBackendTLSEnabled(project *v1alpha1.SupabaseProject) bool {
+	return GatewayEnabled(project) && project.Spec.Gateway.TLS != nil
+}
+
+// BuildHTTPRoute creates an HTTPRoute that forwards all traffic for the
+// configured hostnames to the Kong Service. Realtime gets its own rule with the
+// request timeout disabled so long-lived websocket connections are not cut.
+func BuildHTTPRoute(project *v1alpha1.SupabaseProject) (*gatewayv1.HTTPRoute, error) {
+	kongService, err := (&KongBuilder{}).BuildService(project)
+	if err != nil {
+		return nil, err
+	}
+
+	config := project.Spec.Gateway
+	if config == nil {
+		config = &v1alpha1.GatewayConfig{}
+	}
+
+	portName := "proxy"
+	if config.TLS != nil {
+		portName = "proxy-ssl"
+	}
+	var port gatewayv1.PortNumber
+	for _, servicePort := range kongService.Spec.Ports {
+		if servicePort.Name == portName {
+			port = servicePort.Port
+		}
+	}
+
+	backendRefs := []gatewayv1.HTTPBackendRef{
+		{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Name: gatewayv1.ObjectName(kongService.Name),
+					Port: &port,
+				},
+			},
+		},
+	}
+
+	parentRefs := make([]gatewayv1.ParentReference, 0, len(config.ParentRefs))
+	for _, ref := range config.ParentRefs {
+		parentRef := gatewayv1.ParentReference{
+			Name: gatewayv1.ObjectName(ref.Name),
+			Port: ref.Port,
+		}
+		if ref.Namespace != "" {
+			namespace := gatewayv1.Namespace(ref.Namespace)
+			parentRef.Namespace = &namespace
+		}
+		if ref.SectionName != "" {
+			sectionName := gatewayv1.SectionName(ref.SectionName)
+			parentRef.SectionName = &sectionName
+		}
+		parentRefs = append(parentRefs, parentRef)
+	}
+
+	var hostnames []gatewayv1.Hostname
+	for _, hostname := range config.Hostnames {
+		hostnames = append(hostnames, gatewayv1.Hostname(hostname))
+	}
+
+	pathPrefix := gatewayv1.PathMatchPathPrefix
+	realtimePath := realtimeWebsocketPath
+	rootPath := "/"
+	noTimeout := gatewayv1.Duration("0s")
+	realtimeRuleName := gatewayv1.SectionName("realtime")
+	defaultRuleName := gatewayv1.SectionName("default")
+
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongService.Name,
+			Namespace: project.Namespace,
+			Labels:    kongService.Labels,
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: hostnames,
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Name: &realtimeRuleName,
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{Path: &gatewayv1.HTTPPathMatch{Type: &pathPrefix, Value: &realtimePath}},
+					},
+					BackendRefs: backendRefs,
+					Timeouts: &gatewayv1.HTTPRouteTimeouts{
+						Request:        &noTimeout,
+						BackendRequest: &noTimeout,
+					},
+				},
+				{
+					Name: &defaultRuleName,
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{Path: &gatewayv1.HTTPPathMatch{Type: &pathPrefix, Value: &rootPath}},
+					},
+					BackendRefs: backendRefs,
+				},
+			},
+		},
+	}, nil
+}
+
+// BuildBackendTLSPolicy creates the BackendTLSPolicy that makes the Gateway
+// validate Kong's certificate on the proxy-ssl port.
+func BuildBackendTLSPolicy(project *v1alpha1.SupabaseProject) (*gatewayv1.BackendTLSPolicy, error) {
+	kongService, err := (&KongBuilder{}).BuildService(project)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &v1alpha1.GatewayTLSConfig{}
+	if project.Spec.Gateway != nil && project.Spec.Gateway.TLS != nil {
+		config = project.Spec.Gateway.TLS
+	}
+
+	validation := gatewayv1.BackendTLSPolicyValidation{
+		Hostname: gatewayv1.PreciseHostname(config.Hostname),
+	}
+	for _, name := range config.CACertificateRefs {
+		validation.CACertificateRefs = append(validation.CACertificateRefs, gatewayv1.LocalObjectReference{
+			Group: "",
+			Kind:  "ConfigMap",
+			Name:  gatewayv1.ObjectName(name),
+		})
+	}
+	if config.WellKnownCACertificates != "" {
+		wellKnown := gatewayv1.WellKnownCACertificatesType(config.WellKnownCACertificates)
+		validation.WellKnownCACertificates = &wellKnown
+	}
+
+	sectionName := gatewayv1.SectionName("proxy-ssl")
+
+	return &gatewayv1.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kongService.Name,
+			Namespace: project.Namespace,
+			Labels:    kongService.Labels,
+		},
+		Spec: gatewayv1.BackendTLSPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				{
+					LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+						Group: "",
+						Kind:  "Service",
+						Name:  gatewayv1.ObjectName(kongService.Name),
+					},
+					SectionName: &sectionName,
+				},
+			},
+			Validation: validation,
+		},
+	}, nil
+}
+
+// kongServiceAppProtocol returns the appProtocol for a Kong Service port. It is
+// only set when a Gateway fronts Kong, so Ingress controllers are unaffected.
+func kongServiceAppProtocol(project *v1alpha1.SupabaseProject, portName string) *string {
+	if !GatewayEnabled(project) {
+		return nil
+	}
+
+	protocol := appProtocolWebsocket
+	if portName == "proxy-ssl" {
+		protocol = appProtocolWebsocketTLS
+	}
+	return &protocol
+}
@@ -0,0 +1,195 @@
+package component
+
+import (
+	"strconv"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// imgproxyPort is the port imgproxy binds to, matching the upstream compose file.
+const imgproxyPort = 5001
+
+// ImgproxyEnabled reports whether the project asks for image transformations.
+func ImgproxyEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Imgproxy != nil && project.Spec.Imgproxy.Enabled
+}
+
+// ImgproxyURL returns the in-cluster URL the Storage API uses to reach imgproxy.
+func ImgproxyURL(project *v1alpha1.SupabaseProject) string {
+	return "http://" + project.Name + "-imgproxy:" + strconv.Itoa(imgproxyPort)
+}
+
+type ImgproxyBuilder struct{}
+
+var _ ComponentBuilder = (*ImgproxyBuilder)(nil)
+
+func (b *ImgproxyBuilder) Name() string {
+	return "imgproxy"
+}
+
+func (b *ImgproxyBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv1.Deployment, error) {
+	replicas := int32(1)
+	if project.Spec.Imgproxy != nil && project.Spec.Imgproxy.Replicas > 0 {
+		replicas = project.Spec.Imgproxy.Replicas
+	}
+
+	image := v1alpha1.DefaultImgproxyImage
+	if project.Spec.Imgproxy != nil && project.Spec.Imgproxy.Image != "" {
+		image = project.Spec.Imgproxy.Image
+	}
+
+	resources := getImgproxyDefaultResources()
+	if project.Spec.Imgproxy != nil && project.Spec.Imgproxy.Resources != nil {
+		resources = *project.Spec.Imgproxy.Resources
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "imgproxy",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "image-transformation",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	env := []corev1.EnvVar{
+		{
+			Name:  "IMGPROXY_BIND",
+			Value: ":" + strconv.Itoa(imgproxyPort),
+		},
+		{
+			Name:  "IMGPROXY_LOCAL_FILESYSTEM_ROOT",
+			Value: "/",
+		},
+		{
+			Name:  "IMGPROXY_USE_ETAG",
+			Value: "true",
+		},
+		{
+			Name:  "IMGPROXY_ENABLE_WEBP_DETECTION",
+			Value: "true",
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-imgproxy",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "imgproxy",
+							Image:     image,
+							Resources: resources,
+							Env:       env,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: imgproxyPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// With the file backend, Storage hands imgproxy local:// paths, so imgproxy
+	// reads the same claim. A ReadWriteOnce claim is only reachable from the
+	// node that runs Storage.
+	if StorageFileBackend(project) {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, storageDataVolume(project))
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "storage-data",
+			MountPath: fileStorageBackendPath,
+			ReadOnly:  true,
+		})
+		if !storageSharedVolume(project) {
+			podSpec.Affinity = &corev1.Affinity{
+				PodAffinity: &corev1.PodAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+						{
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"app.kubernetes.io/name":     "storage",
+									"app.kubernetes.io/instance": project.Name,
+								},
+							},
+							TopologyKey: "kubernetes.io/hostname",
+						},
+					},
+				},
+			}
+		}
+	}
+
+	if project.Spec.Imgproxy != nil && len(project.Spec.Imgproxy.ExtraEnv) > 0 {
+		deployment.Spec.Template.Spec.Containers[0].Env = append(
+			deployment.Spec.Template.Spec.Containers[0].Env,
+			project.Spec.Imgproxy.ExtraEnv...,
+		)
+	}
+
+	return deployment, nil
+}
+
+func (b *ImgproxyBuilder) BuildService(project *v1alpha1.SupabaseProject) (*corev1.Service, error) {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "imgproxy",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "image-transformation",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-imgproxy",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       imgproxyPort,
+					TargetPort: intstr.FromInt(imgproxyPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}, nil
+}
+
+func getImgproxyDefaultResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("128Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package component
+
+import (
+	"fmt"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IngressEnabled reports whether the project asks the operator to manage an
+// Ingress in front of Kong.
+func IngressEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Ingress != nil && project.Spec.Ingress.Enabled
+}
+
+// IngressURL returns the external base URL served by the project's Ingress,
+// or an empty string when the Ingress is disabled or has no host.
+func IngressURL(project *v1alpha1.SupabaseProject) string {
+	if !IngressEnabled(project) || project.Spec.Ingress.Host == "" {
+		return ""
+	}
+
+	scheme := "http"
+	if project.Spec.Ingress.TLSSecretName != "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s", scheme, project.Spec.Ingress.Host)
+}
+
+// BuildIngress creates an Ingress that routes all traffic for the configured
+// host to the Kong proxy port. Kong is the only entrypoint; every Supabase
+// API path is dispatched from its declarative config.
+func BuildIngress(project *v1alpha1.SupabaseProject) *networkingv1.Ingress {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "kong",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "api-gateway",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	config := project.Spec.Ingress
+	if config == nil {
+		config = &v1alpha1.IngressConfig{}
+	}
+
+	pathType := networkingv1.PathTypePrefix
+	rule := networkingv1.IngressRule{
+		Host: config.Host,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: project.Name + "-kong",
+								Port: networkingv1.ServiceBackendPort{
+									Name: "proxy",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        project.Name + "-kong",
+			Namespace:   project.Namespace,
+			Labels:      labels,
+			Annotations: config.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: config.ClassName,
+			Rules:            []networkingv1.IngressRule{rule},
+		},
+	}
+
+	if config.TLSSecretName != "" {
+		tls := networkingv1.IngressTLS{SecretName: config.TLSSecretName}
+		if config.Host != "" {
+			tls.Hosts = []string{config.Host}
+		}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+
+	return ingress
+}
@@ -0,0 +1,69 @@
+package component
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+)
+
+// JWTChecksumAnnotation carries a digest of the <project>-jwt Secret on the
+// pods of the components that read it, so they roll when the secret rotates.
+const JWTChecksumAnnotation = "supabase.strrl.dev/jwt-checksum"
+
+// Keys of the <project>-jwt Secret holding the opaque API keys. They are
+// independent of the JWT secret, so a JWT rotation keeps them, and Kong maps
+// them to the current anon and service role keys.
+const (
+	PublishableKeyKey = "publishable-key"
+	SecretKeyKey      = "secret-key"
+)
+
+// Keys of the <project>-jwt Secret that are only present while the previous
+// secret of a rotation is still accepted.
+const (
+	JWTPreviousSecretKey      = "previous-jwt-secret"
+	JWTPreviousAnonKey        = "previous-anon-key"
+	JWTPreviousServiceRoleKey = "previous-service-role-key"
+)
+
+// JWTJWKSKey holds the JSON Web Key Set PostgREST verifies tokens with. It
+// contains the current secret and, during a rotation's grace period, the
+// previous one.
+const JWTJWKSKey = "jwt-jwks"
+
+// Keys of the <project>-jwt Secret that are only present when
+// spec.jwt.signingAlgorithm is asymmetric. JWTSigningKeyKey holds the private
+// JWK Auth signs access tokens with. JWTAuthKeysKey holds the GOTRUE_JWT_KEYS
+// value: that key plus the HS256 secrets Auth still verifies tokens with.
+const (
+	JWTSigningKeyKey = "jwt-signing-key"
+	JWTAuthKeysKey   = "auth-jwt-keys"
+)
+
+// AsymmetricJWTEnabled reports whether Auth signs access tokens with an
+// asymmetric key instead of the shared JWT secret.
+func AsymmetricJWTEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.JWT != nil &&
+		project.Spec.JWT.SigningAlgorithm != "" &&
+		project.Spec.JWT.SigningAlgorithm != "HS256"
+}
+
+// JWTChecksum digests the data of the <project>-jwt Secret. The result only
+// depends on keys and values, not on map order.
+func JWTChecksum(data map[string][]byte) string {
+	// encoding/json writes map keys in sorted order.
+	encoded, _ := json.Marshal(data)
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// jwtPodAnnotations returns the pod template annotations of a component that
+// reads the <project>-jwt Secret.
+func jwtPodAnnotations(checksum string) map[string]string {
+	if checksum == "" {
+		return nil
+	}
+	return map[string]string{JWTChecksumAnnotation: checksum}
+}
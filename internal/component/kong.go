@@ -1,6 +1,10 @@
 package component
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/strrl/supabase-operator/api/v1alpha1"
@@ -8,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -17,14 +22,22 @@ import (
 const kongDeclarativeConfigPath = "/usr/local/kong/kong.yml"
 
 // kongEntrypointScript mirrors upstream supabase docker/volumes/api/kong-entrypoint.sh.
-// The operator does not support opaque API keys yet, so only the legacy
-// passthrough branch of the Lua expressions is kept. Environment variable
-// substitution uses awk instead of eval/echo to preserve YAML quoting.
+// The Lua expressions swap the opaque publishable and secret keys for the anon
+// and service role JWTs the other components verify, and pass legacy JWT API
+// keys and user tokens through. The opaque keys are always generated, so
+// upstream's fallback for installs without them is not needed. Environment
+// variable substitution uses awk instead of eval/echo to preserve YAML
+// quoting. The credentials of the previous API keys are only kept while a JWT
+// rotation's grace period sets them.
 const kongEntrypointScript = `#!/bin/sh
-export LUA_AUTH_EXPR="\$((headers.authorization ~= nil and headers.authorization:sub(1, 10) ~= 'Bearer sb_' and headers.authorization) or headers.apikey)"
-export LUA_RT_WS_EXPR="\$(query_params.apikey)"
+export LUA_AUTH_EXPR="\$((headers.authorization ~= nil and headers.authorization:sub(1, 10) ~= 'Bearer sb_' and headers.authorization) or (headers.apikey == '$SUPABASE_SECRET_KEY' and 'Bearer $SUPABASE_SERVICE_KEY') or (headers.apikey == '$SUPABASE_PUBLISHABLE_KEY' and 'Bearer $SUPABASE_ANON_KEY') or headers.apikey)"
+export LUA_RT_WS_EXPR="\$((query_params.apikey == '$SUPABASE_SECRET_KEY' and '$SUPABASE_SERVICE_KEY') or (query_params.apikey == '$SUPABASE_PUBLISHABLE_KEY' and '$SUPABASE_ANON_KEY') or query_params.apikey)"
 
-awk '{
+awk '/- key: \$SUPABASE_PREVIOUS_[A-Z_]+$/ {
+  varname = substr($3, 2)
+  if (!(varname in ENVIRON) || ENVIRON[varname] == "") next
+}
+{
   result = ""
   rest = $0
   while (match(rest, /\$[A-Za-z_][A-Za-z_0-9]*/)) {
@@ -43,8 +56,8 @@ exec /entrypoint.sh kong docker-start
 `
 
 // kongDeclarativeConfigTemplate mirrors upstream supabase docker/volumes/api/kong.yml.
-// {{PROJECT}} is replaced with the SupabaseProject name. Routes for components
-// the operator does not deploy (edge functions, analytics) are omitted.
+// {{PROJECT}} is replaced with the SupabaseProject name. functions-v1 and
+// analytics-v1 are dropped unless the matching optional component is enabled.
 const kongDeclarativeConfigTemplate = `_format_version: '2.1'
 _transform: true
 
@@ -53,9 +66,13 @@ consumers:
   - username: anon
     keyauth_credentials:
       - key: $SUPABASE_ANON_KEY
+      - key: $SUPABASE_PUBLISHABLE_KEY
+      - key: $SUPABASE_PREVIOUS_ANON_KEY
   - username: service_role
     keyauth_credentials:
       - key: $SUPABASE_SERVICE_KEY
+      - key: $SUPABASE_SECRET_KEY
+      - key: $SUPABASE_PREVIOUS_SERVICE_KEY
 
 acls:
   - consumer: anon
@@ -346,6 +363,24 @@ services:
                 kong.service.request.clear_header("authorization")
               end
 
+  - name: functions-v1
+    url: http://{{PROJECT}}-functions:9000/
+    routes:
+      - name: functions-v1-all
+        strip_path: true
+        paths:
+          - /functions/v1/
+    plugins:
+      - name: cors
+
+  - name: analytics-v1
+    url: http://{{PROJECT}}-analytics:4000/
+    routes:
+      - name: analytics-v1-all
+        strip_path: true
+        paths:
+          - /analytics/v1/
+
   - name: well-known-oauth
     url: http://{{PROJECT}}-auth:9999/.well-known/oauth-authorization-server
     routes:
@@ -400,7 +435,75 @@ services:
           hide_credentials: true
 `
 
-type KongBuilder struct{}
+// kongBuiltinPlugins are the plugins the declarative config template and the
+// typed settings of spec.kong use.
+var kongBuiltinPlugins = []string{
+	"request-transformer",
+	"cors",
+	"key-auth",
+	"acl",
+	"basic-auth",
+	"request-termination",
+	"ip-restriction",
+	"post-function",
+	"rate-limiting",
+	"pre-function",
+}
+
+// kongPlugins returns the KONG_PLUGINS list: the built-in plugins followed by
+// the ones spec.kong adds.
+func kongPlugins(project *v1alpha1.SupabaseProject) []string {
+	plugins := slices.Clone(kongBuiltinPlugins)
+	if project.Spec.Kong == nil {
+		return plugins
+	}
+
+	add := func(extra []v1alpha1.KongPlugin) {
+		for _, plugin := range extra {
+			if !slices.Contains(plugins, plugin.Name) {
+				plugins = append(plugins, plugin.Name)
+			}
+		}
+	}
+	add(project.Spec.Kong.GlobalPlugins)
+	for _, service := range project.Spec.Kong.ExtraServices {
+		add(service.Plugins)
+	}
+	return plugins
+}
+
+// KongBuiltinServiceNames returns the names of the services in the declarative
+// config template, including the optional ones.
+func KongBuiltinServiceNames() []string {
+	var names []string
+	for _, line := range strings.Split(kongDeclarativeConfigTemplate, "\n") {
+		if name, ok := strings.CutPrefix(line, "  - name: "); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// KongBuiltinPaths returns the route paths of the services in the declarative
+// config template, including the optional ones.
+func KongBuiltinPaths() []string {
+	var paths []string
+	for _, line := range strings.Split(kongDeclarativeConfigTemplate, "\n") {
+		if path, ok := strings.CutPrefix(line, "          - /"); ok {
+			paths = append(paths, "/"+path)
+		}
+	}
+	return paths
+}
+
+// KongBuilder builds Kong. The API keys are rendered into the declarative
+// config at startup, so JWTChecksum is stamped on the pod template to pick up
+// rotated keys. APIKeys are the ready SupabaseAPIKeys of the project, whose
+// tokens are passed in the same way.
+type KongBuilder struct {
+	JWTChecksum string
+	APIKeys     []KongAPIKey
+}
 
 var _ ComponentBuilder = (*KongBuilder)(nil)
 
@@ -432,8 +535,6 @@ func (b *KongBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		"app.kubernetes.io/managed-by": "supabase-operator",
 	}
 
-	plugins := "request-transformer,cors,key-auth,acl,basic-auth,request-termination,ip-restriction,post-function"
-
 	env := []corev1.EnvVar{
 		{
 			Name:  "KONG_DATABASE",
@@ -477,7 +578,7 @@ func (b *KongBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		},
 		{
 			Name:  "KONG_PLUGINS",
-			Value: plugins,
+			Value: strings.Join(kongPlugins(project), ","),
 		},
 		{
 			Name:  "KONG_NGINX_PROXY_PROXY_BUFFER_SIZE",
@@ -489,6 +590,8 @@ func (b *KongBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 		},
 	}
 
+	// The previous API keys only exist while a JWT rotation's grace period lasts.
+	optional := true
 	env = append(env,
 		corev1.EnvVar{
 			Name: "SUPABASE_ANON_KEY",
@@ -508,6 +611,44 @@ func (b *KongBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 				},
 			},
 		},
+		corev1.EnvVar{
+			Name: "SUPABASE_PUBLISHABLE_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: project.Name + "-jwt"},
+					Key:                  PublishableKeyKey,
+				},
+			},
+		},
+		corev1.EnvVar{
+			Name: "SUPABASE_SECRET_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: project.Name + "-jwt"},
+					Key:                  SecretKeyKey,
+				},
+			},
+		},
+		corev1.EnvVar{
+			Name: "SUPABASE_PREVIOUS_ANON_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: project.Name + "-jwt"},
+					Key:                  JWTPreviousAnonKey,
+					Optional:             &optional,
+				},
+			},
+		},
+		corev1.EnvVar{
+			Name: "SUPABASE_PREVIOUS_SERVICE_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: project.Name + "-jwt"},
+					Key:                  JWTPreviousServiceRoleKey,
+					Optional:             &optional,
+				},
+			},
+		},
 	)
 
 	usernameEnv := corev1.EnvVar{Name: "DASHBOARD_USERNAME", Value: "supabase"}
@@ -535,6 +676,26 @@ func (b *KongBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 	}
 	env = append(env, usernameEnv, passwordEnv)
 
+	for i, key := range sortedKongAPIKeys(b.APIKeys) {
+		env = append(env, corev1.EnvVar{
+			Name: kongAPIKeyEnv(i),
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: key.SecretName},
+					Key:                  APIKeySecretKey,
+				},
+			},
+		})
+	}
+
+	podAnnotations := jwtPodAnnotations(b.JWTChecksum)
+	if checksum := kongAPIKeysChecksum(b.APIKeys); checksum != "" {
+		if podAnnotations == nil {
+			podAnnotations = map[string]string{}
+		}
+		podAnnotations[KongAPIKeysChecksumAnnotation] = checksum
+	}
+
 	healthProbe := &corev1.Probe{
 		ProbeHandler: corev1.ProbeHandler{
 			Exec: &corev1.ExecAction{
@@ -560,7 +721,8 @@ func (b *KongBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: podAnnotations,
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -638,7 +800,9 @@ func (b *KongBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv
 	return deployment, nil
 }
 
-func BuildKongConfigMap(project *v1alpha1.SupabaseProject) *corev1.ConfigMap {
+// BuildKongConfigMap renders the declarative config and entrypoint of Kong.
+// apiKeys are registered as consumers next to the built-in ones.
+func BuildKongConfigMap(project *v1alpha1.SupabaseProject, apiKeys []KongAPIKey) *corev1.ConfigMap {
 	labels := map[string]string{
 		"app.kubernetes.io/name":       "kong",
 		"app.kubernetes.io/instance":   project.Name,
@@ -648,6 +812,24 @@ func BuildKongConfigMap(project *v1alpha1.SupabaseProject) *corev1.ConfigMap {
 	}
 
 	kongConfig := strings.ReplaceAll(kongDeclarativeConfigTemplate, "{{PROJECT}}", project.Name)
+	if postgrestGraphQLDisabled(project) {
+		kongConfig = removeKongService(kongConfig, "graphql-v1")
+	}
+	if !FunctionsEnabled(project) {
+		kongConfig = removeKongService(kongConfig, "functions-v1")
+	}
+	if !AnalyticsEnabled(project) {
+		kongConfig = removeKongService(kongConfig, "analytics-v1")
+	}
+	kongConfig = addKongAPIKeyConsumers(kongConfig, apiKeys)
+	globalPlugins := []v1alpha1.KongPlugin{kongAPIKeyRevocationPlugin(apiKeys)}
+	if project.Spec.Kong != nil {
+		kongConfig = addKongRateLimits(kongConfig, project.Spec.Kong.RateLimits)
+		kongConfig = addKongCORS(kongConfig, project.Spec.Kong.CORS)
+		kongConfig = addKongExtraServices(kongConfig, project.Spec.Kong.ExtraServices)
+		globalPlugins = append(globalPlugins, project.Spec.Kong.GlobalPlugins...)
+	}
+	kongConfig = addKongGlobalPlugins(kongConfig, globalPlugins)
 
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -662,6 +844,22 @@ func BuildKongConfigMap(project *v1alpha1.SupabaseProject) *corev1.ConfigMap {
 	}
 }
 
+// removeKongService drops a top-level service block, including its trailing
+// blank line, from the declarative config.
+func removeKongService(config, name string) string {
+	start := strings.Index(config, "\n  - name: "+name+"\n")
+	if start < 0 {
+		return config
+	}
+	start++
+
+	end := strings.Index(config[start:], "\n\n  - name: ")
+	if end < 0 {
+		return config[:start]
+	}
+	return config[:start] + config[start+end+2:]
+}
+
 func (b *KongBuilder) BuildService(project *v1alpha1.SupabaseProject) (*corev1.Service, error) {
 	labels := map[string]string{
 		"app.kubernetes.io/name":       "kong",
@@ -682,16 +880,18 @@ func (b *KongBuilder) BuildService(project *v1alpha1.SupabaseProject) (*corev1.S
 			Type:     corev1.ServiceTypeClusterIP,
 			Ports: []corev1.ServicePort{
 				{
-					Name:       "proxy",
-					Port:       8000,
-					TargetPort: intstr.FromInt(8000),
-					Protocol:   corev1.ProtocolTCP,
+					Name:        "proxy",
+					Port:        8000,
+					TargetPort:  intstr.FromInt(8000),
+					Protocol:    corev1.ProtocolTCP,
+					AppProtocol: kongServiceAppProtocol(project, "proxy"),
 				},
 				{
-					Name:       "proxy-ssl",
-					Port:       8443,
-					TargetPort: intstr.FromInt(8443),
-					Protocol:   corev1.ProtocolTCP,
+					Name:        "proxy-ssl",
+					Port:        8443,
+					TargetPort:  intstr.FromInt(8443),
+					Protocol:    corev1.ProtocolTCP,
+					AppProtocol: kongServiceAppProtocol(project, "proxy-ssl"),
 				},
 			},
 		},
@@ -710,3 +910,319 @@ func getKongDefaultResources() corev1.ResourceRequirements {
 		},
 	}
 }
+
+// addKongExtraServices appends spec.kong.extraServices to the services of the
+// declarative config, which is the last top-level section of the template.
+// Values are written as JSON, which YAML reads as is.
+func addKongExtraServices(config string, services []v1alpha1.KongService) string {
+	if len(services) == 0 {
+		return config
+	}
+
+	var b strings.Builder
+	b.WriteString(config)
+	for _, service := range services {
+		fmt.Fprintf(&b, "\n  - name: %s\n    url: %s\n    routes:\n", service.Name, kongQuote(service.URL))
+		for _, route := range service.Routes {
+			stripPath := route.StripPath == nil || *route.StripPath
+			fmt.Fprintf(&b, "      - strip_path: %t\n        paths:\n", stripPath)
+			for _, path := range route.Paths {
+				fmt.Fprintf(&b, "          - %s\n", kongQuote(path))
+			}
+			if len(route.Methods) > 0 {
+				b.WriteString("        methods:\n")
+				for _, method := range route.Methods {
+					fmt.Fprintf(&b, "          - %s\n", kongQuote(method))
+				}
+			}
+		}
+		if len(service.Plugins) > 0 {
+			b.WriteString("    plugins:\n")
+			writeKongPlugins(&b, service.Plugins, "      ")
+		}
+	}
+	return b.String()
+}
+
+// addKongGlobalPlugins adds plugins as the top-level plugins of the
+// declarative config.
+func addKongGlobalPlugins(config string, plugins []v1alpha1.KongPlugin) string {
+	if len(plugins) == 0 {
+		return config
+	}
+
+	var b strings.Builder
+	b.WriteString(config)
+	b.WriteString("\nplugins:\n")
+	writeKongPlugins(&b, plugins, "  ")
+	return b.String()
+}
+
+func writeKongPlugins(b *strings.Builder, plugins []v1alpha1.KongPlugin, indent string) {
+	for _, plugin := range plugins {
+		fmt.Fprintf(b, "%s- name: %s\n", indent, plugin.Name)
+		if plugin.Config == nil || len(plugin.Config.Raw) == 0 {
+			continue
+		}
+		var config bytes.Buffer
+		if err := json.Compact(&config, plugin.Config.Raw); err != nil {
+			continue
+		}
+		fmt.Fprintf(b, "%s  config: %s\n", indent, config.String())
+	}
+}
+
+// kongQuote writes s as a double-quoted YAML scalar.
+func kongQuote(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// kongRouteGroups maps the route groups of spec.kong to the name prefix of the
+// built-in services they cover.
+var kongRouteGroups = []struct {
+	name          string
+	servicePrefix string
+}{
+	{"auth", "auth-v1"},
+	{"rest", "rest-v1"},
+	{"graphql", "graphql-v1"},
+	{"storage", "storage-v1"},
+	{"realtime", "realtime-v1"},
+	{"functions", "functions-v1"},
+}
+
+// KongRateLimitGroup is the limit of one route group of spec.kong.rateLimits.
+type KongRateLimitGroup struct {
+	Group string
+	Limit *v1alpha1.KongRateLimit
+}
+
+// KongRateLimitGroups returns the route groups limits sets, in a fixed order.
+func KongRateLimitGroups(limits *v1alpha1.KongRateLimits) []KongRateLimitGroup {
+	if limits == nil {
+		return nil
+	}
+
+	byGroup := map[string]*v1alpha1.KongRateLimit{
+		"auth":      limits.Auth,
+		"rest":      limits.Rest,
+		"graphql":   limits.GraphQL,
+		"storage":   limits.Storage,
+		"realtime":  limits.Realtime,
+		"functions": limits.Functions,
+	}
+	var groups []KongRateLimitGroup
+	for _, group := range kongRouteGroups {
+		if limit := byGroup[group.name]; limit != nil {
+			groups = append(groups, KongRateLimitGroup{Group: group.name, Limit: limit})
+		}
+	}
+	return groups
+}
+
+// addKongRateLimits adds the rate-limiting plugin to the services of every
+// limited route group. Counters use the local policy, so Kong needs no
+// database or Redis.
+func addKongRateLimits(config string, limits *v1alpha1.KongRateLimits) string {
+	for _, group := range KongRateLimitGroups(limits) {
+		limitBy := group.Limit.LimitBy
+		if limitBy == "" {
+			limitBy = "ip"
+		}
+		pluginConfig := map[string]any{
+			"policy":   "local",
+			"limit_by": limitBy,
+		}
+		if group.Limit.Second != nil {
+			pluginConfig["second"] = *group.Limit.Second
+		}
+		if group.Limit.Minute != nil {
+			pluginConfig["minute"] = *group.Limit.Minute
+		}
+		if group.Limit.Hour != nil {
+			pluginConfig["hour"] = *group.Limit.Hour
+		}
+		raw, _ := json.Marshal(pluginConfig)
+
+		var plugin strings.Builder
+		writeKongPlugins(&plugin, []v1alpha1.KongPlugin{{
+			Name:   "rate-limiting",
+			Config: &runtime.RawExtension{Raw: raw},
+		}}, "      ")
+		config = appendKongGroupPlugins(config, group.Group, plugin.String())
+	}
+	return config
+}
+
+// kongServiceGroup returns the route group of a built-in service, or "" when
+// it belongs to none.
+func kongServiceGroup(service string) string {
+	for _, group := range kongRouteGroups {
+		if service == group.servicePrefix || strings.HasPrefix(service, group.servicePrefix+"-") {
+			return group.name
+		}
+	}
+	return ""
+}
+
+// appendKongGroupPlugins appends plugins, rendered at the indentation of a
+// service's plugin list, to every built-in service of group. The plugin list
+// is the last key of those services.
+func appendKongGroupPlugins(config, group, plugins string) string {
+	const serviceStart = "\n  - name: "
+
+	var b strings.Builder
+	rest := config
+	for {
+		start := strings.Index(rest, serviceStart)
+		if start < 0 {
+			break
+		}
+		nameStart := start + len(serviceStart)
+		nameEnd := nameStart + strings.IndexByte(rest[nameStart:], '\n')
+		if kongServiceGroup(rest[nameStart:nameEnd]) != group {
+			b.WriteString(rest[:nameEnd])
+			rest = rest[nameEnd:]
+			continue
+		}
+
+		end := strings.Index(rest[nameEnd:], "\n\n")
+		if end < 0 {
+			end = len(rest)
+		} else {
+			end += nameEnd + 1
+		}
+		b.WriteString(rest[:end])
+		b.WriteString(plugins)
+		rest = rest[end:]
+	}
+	b.WriteString(rest)
+	return b.String()
+}
+
+// addKongCORS configures every cors plugin of the built-in services with the
+// policy of spec.kong.cors, merged with the override of the service's route
+// group.
+func addKongCORS(config string, cors *v1alpha1.KongCORS) string {
+	if cors == nil {
+		return config
+	}
+
+	lines := strings.Split(config, "\n")
+	out := make([]string, 0, len(lines))
+	service := ""
+	for i, line := range lines {
+		out = append(out, line)
+		if name, ok := strings.CutPrefix(line, "  - name: "); ok {
+			service = name
+			continue
+		}
+		if line != "      - name: cors" || (i+1 < len(lines) && strings.HasPrefix(lines[i+1], "        config:")) {
+			continue
+		}
+		if pluginConfig := kongCORSConfig(cors, kongServiceGroup(service)); pluginConfig != "" {
+			out = append(out, "        config: "+pluginConfig)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// KongCORSGroup is the policy of one route group overridden in spec.kong.cors.
+type KongCORSGroup struct {
+	Group  string
+	Policy v1alpha1.KongCORSPolicy
+}
+
+// KongCORSGroups returns the route groups cors overrides, in a fixed order,
+// with the override merged into the policy.
+func KongCORSGroups(cors *v1alpha1.KongCORS) []KongCORSGroup {
+	if cors == nil {
+		return nil
+	}
+
+	var groups []KongCORSGroup
+	for _, group := range kongRouteGroups {
+		if kongCORSOverride(cors.Overrides, group.name) != nil {
+			groups = append(groups, KongCORSGroup{Group: group.name, Policy: kongCORSPolicy(cors, group.name)})
+		}
+	}
+	return groups
+}
+
+// kongCORSPolicy returns the policy of cors with the override of group
+// merged in field by field.
+func kongCORSPolicy(cors *v1alpha1.KongCORS, group string) v1alpha1.KongCORSPolicy {
+	policy := cors.KongCORSPolicy
+	override := kongCORSOverride(cors.Overrides, group)
+	if override == nil {
+		return policy
+	}
+	if len(override.Origins) > 0 {
+		policy.Origins = override.Origins
+	}
+	if len(override.Methods) > 0 {
+		policy.Methods = override.Methods
+	}
+	if len(override.Headers) > 0 {
+		policy.Headers = override.Headers
+	}
+	if override.Credentials != nil {
+		policy.Credentials = override.Credentials
+	}
+	if override.MaxAgeSeconds != nil {
+		policy.MaxAgeSeconds = override.MaxAgeSeconds
+	}
+	return policy
+}
+
+// kongCORSConfig renders the cors plugin config of a route group as JSON, or
+// returns "" when no field is set.
+func kongCORSConfig(cors *v1alpha1.KongCORS, group string) string {
+	policy := kongCORSPolicy(cors, group)
+
+	pluginConfig := map[string]any{}
+	if len(policy.Origins) > 0 {
+		pluginConfig["origins"] = policy.Origins
+	}
+	if len(policy.Methods) > 0 {
+		pluginConfig["methods"] = policy.Methods
+	}
+	if len(policy.Headers) > 0 {
+		pluginConfig["headers"] = policy.Headers
+	}
+	if policy.Credentials != nil {
+		pluginConfig["credentials"] = *policy.Credentials
+	}
+	if policy.MaxAgeSeconds != nil {
+		pluginConfig["max_age"] = *policy.MaxAgeSeconds
+	}
+	if len(pluginConfig) == 0 {
+		return ""
+	}
+
+	// encoding/json writes map keys in sorted order.
+	rendered, _ := json.Marshal(pluginConfig)
+	return string(rendered)
+}
+
+func kongCORSOverride(overrides *v1alpha1.KongCORSOverrides, group string) *v1alpha1.KongCORSPolicy {
+	if overrides == nil {
+		return nil
+	}
+	switch group {
+	case "auth":
+		return overrides.Auth
+	case "rest":
+		return overrides.Rest
+	case "graphql":
+		return overrides.GraphQL
+	case "storage":
+		return overrides.Storage
+	case "realtime":
+		return overrides.Realtime
+	case "functions":
+		return overrides.Functions
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+package component
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	poolerAPIPort         = 4000
+	poolerSessionPort     = 5432
+	poolerTransactionPort = 6543
+
+	// PoolerSecretKeyBase and PoolerVaultEncKey are the keys of the generated
+	// <project>-pooler Secret.
+	PoolerSecretKeyBase = "secret-key-base"
+	PoolerVaultEncKey   = "vault-enc-key"
+)
+
+// poolerTenantScript mirrors upstream supabase docker/volumes/pooler/pooler.exs.
+// It registers the project as a Supavisor tenant on first start and updates
+// the tenant and its manager user on later starts, so changes to the pool
+// settings, which roll the pods, take effect. The database host comes from the
+// environment instead of the compose service name, and upstream TLS follows
+// spec.database.sslMode.
+const poolerTenantScript = `{:ok, _} = Application.ensure_all_started(:supavisor)
+
+{:ok, version} =
+  case Supavisor.Repo.query!("select version()") do
+    %{rows: [[ver]]} -> Supavisor.Helpers.parse_pg_version(ver)
+    _ -> nil
+  end
+
+params = %{
+  "external_id" => System.get_env("POOLER_TENANT_ID"),
+  "db_host" => System.get_env("POSTGRES_HOST"),
+  "db_port" => System.get_env("POSTGRES_PORT"),
+  "db_database" => System.get_env("POSTGRES_DB"),
+  "require_user" => false,
+  "upstream_ssl" => System.get_env("POOLER_UPSTREAM_SSL") == "true",
+  "upstream_verify" => "none",
+  "auth_query" => "SELECT * FROM pgbouncer.get_auth($1)",
+  "default_max_clients" => System.get_env("POOLER_MAX_CLIENT_CONN"),
+  "default_pool_size" => System.get_env("POOLER_DEFAULT_POOL_SIZE"),
+  "default_parameter_status" => %{"server_version" => version},
+  "users" => [%{
+    "db_user" => "pgbouncer",
+    "db_password" => System.get_env("POSTGRES_PASSWORD"),
+    "mode_type" => System.get_env("POOLER_POOL_MODE"),
+    "pool_size" => System.get_env("POOLER_DEFAULT_POOL_SIZE"),
+    "is_manager" => true
+  }]
+}
+
+case Supavisor.Tenants.get_tenant_by_external_id(params["external_id"]) do
+  nil ->
+    {:ok, _} = Supavisor.Tenants.create_tenant(params)
+
+  tenant ->
+    {:ok, _} = Supavisor.Tenants.update_tenant(tenant, Map.delete(params, "users"))
+
+    [user_params] = params["users"]
+
+    for user <- Supavisor.Repo.preload(tenant, :users).users, user.is_manager do
+      {:ok, _} = user |> Supavisor.Tenants.User.changeset(user_params) |> Supavisor.Repo.update()
+    end
+end
+`
+
+// PoolerEnabled reports whether the project asks for Supavisor.
+func PoolerEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Pooler != nil && project.Spec.Pooler.Enabled
+}
+
+// poolerTenantID returns the tenant clients append to their user name.
+func poolerTenantID(project *v1alpha1.SupabaseProject) string {
+	if project.Spec.Pooler != nil && project.Spec.Pooler.TenantID != "" {
+		return project.Spec.Pooler.TenantID
+	}
+	if project.Spec.ProjectID != "" {
+		return project.Spec.ProjectID
+	}
+	return project.Name
+}
+
+// poolerRoutes reports whether the named component reaches PostgreSQL through
+// the pooler.
+func poolerRoutes(project *v1alpha1.SupabaseProject, component string) bool {
+	return PoolerEnabled(project) && slices.Contains(project.Spec.Pooler.RouteComponents, component)
+}
+
+// poolerDatabaseURL returns a connection URL through the pooler for user. The
+// hop to the pooler stays in-cluster and is not encrypted; the pooler itself
+// uses TLS towards PostgreSQL unless sslMode is disable.
+func poolerDatabaseURL(project *v1alpha1.SupabaseProject, user string, port int) string {
+	return "postgres://" + user + "." + poolerTenantID(project) + ":$(DB_PASSWORD)@" +
+		project.Name + "-pooler:" + strconv.Itoa(port) + "/$(DB_NAME)?sslmode=disable"
+}
+
+// BuildPoolerConfigMap creates the ConfigMap holding the tenant bootstrap script.
+func BuildPoolerConfigMap(project *v1alpha1.SupabaseProject) *corev1.ConfigMap {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "pooler",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "connection-pooler",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-pooler-config",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			"pooler.exs": poolerTenantScript,
+		},
+	}
+}
+
+// SupavisorBuilder builds Supavisor, which authenticates its management API
+// with the JWT secret. JWTChecksum is stamped on the pod template.
+type SupavisorBuilder struct {
+	JWTChecksum string
+}
+
+var _ ComponentBuilder = (*SupavisorBuilder)(nil)
+
+func (b *SupavisorBuilder) Name() string {
+	return "pooler"
+}
+
+func (b *SupavisorBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*appsv1.Deployment, error) {
+	config := project.Spec.Pooler
+	if config == nil {
+		config = &v1alpha1.PoolerConfig{}
+	}
+
+	replicas := int32(1)
+	if config.Replicas > 0 {
+		replicas = config.Replicas
+	}
+
+	image := v1alpha1.DefaultPoolerImage
+	if config.Image != "" {
+		image = config.Image
+	}
+
+	resources := getPoolerDefaultResources()
+	if config.Resources != nil {
+		resources = *config.Resources
+	}
+
+	poolMode := "transaction"
+	if config.PoolMode != "" {
+		poolMode = config.PoolMode
+	}
+
+	defaultPoolSize := int32(20)
+	if config.DefaultPoolSize > 0 {
+		defaultPoolSize = config.DefaultPoolSize
+	}
+
+	maxClientConnections := int32(100)
+	if config.MaxClientConnections > 0 {
+		maxClientConnections = config.MaxClientConnections
+	}
+
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "pooler",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "connection-pooler",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	sslMode := project.Spec.Database.SSLMode
+	if sslMode == "" {
+		sslMode = defaultSSLMode
+	}
+
+	dbSecretName := project.Spec.Database.SecretRef.Name
+	jwtSecretName := project.Name + "-jwt"
+	poolerSecretName := project.Name + "-pooler"
+
+	env := []corev1.EnvVar{
+		secretKeyEnvVar("POSTGRES_HOST", dbSecretName, "host"),
+		secretKeyEnvVar("POSTGRES_PORT", dbSecretName, "port"),
+		secretKeyEnvVar("POSTGRES_DB", dbSecretName, "database"),
+		secretKeyEnvVar("POSTGRES_USER", dbSecretName, "username"),
+		secretKeyEnvVar("POSTGRES_PASSWORD", dbSecretName, "password"),
+		{
+			Name:  "DATABASE_URL",
+			Value: "ecto://$(POSTGRES_USER):$(POSTGRES_PASSWORD)@$(POSTGRES_HOST):$(POSTGRES_PORT)/_supabase",
+		},
+		{
+			Name:  "PORT",
+			Value: strconv.Itoa(poolerAPIPort),
+		},
+		{
+			Name:  "PROXY_PORT_SESSION",
+			Value: strconv.Itoa(poolerSessionPort),
+		},
+		{
+			Name:  "PROXY_PORT_TRANSACTION",
+			Value: strconv.Itoa(poolerTransactionPort),
+		},
+		{
+			Name:  "CLUSTER_POSTGRES",
+			Value: "true",
+		},
+		secretKeyEnvVar("SECRET_KEY_BASE", poolerSecretName, PoolerSecretKeyBase),
+		secretKeyEnvVar("VAULT_ENC_KEY", poolerSecretName, PoolerVaultEncKey),
+		// Supavisor checks this secret only on its management API, which
+		// takes HS256 service role keys; client connections authenticate with
+		// database credentials.
+		secretKeyEnvVar("API_JWT_SECRET", jwtSecretName, "jwt-secret"),
+		secretKeyEnvVar("METRICS_JWT_SECRET", jwtSecretName, "jwt-secret"),
+		{
+			Name:  "REGION",
+			Value: "local",
+		},
+		{
+			Name:  "ERL_AFLAGS",
+			Value: "-proto_dist inet_tcp",
+		},
+		{
+			Name:  "POOLER_TENANT_ID",
+			Value: poolerTenantID(project),
+		},
+		{
+			Name:  "POOLER_DEFAULT_POOL_SIZE",
+			Value: strconv.Itoa(int(defaultPoolSize)),
+		},
+		{
+			Name:  "POOLER_MAX_CLIENT_CONN",
+			Value: strconv.Itoa(int(maxClientConnections)),
+		},
+		{
+			Name:  "POOLER_POOL_MODE",
+			Value: poolMode,
+		},
+		{
+			Name:  "POOLER_UPSTREAM_SSL",
+			Value: strconv.FormatBool(sslMode != "disable"),
+		},
+		{
+			Name:  "DB_POOL_SIZE",
+			Value: "5",
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-pooler",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: jwtPodAnnotations(b.JWTChecksum),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:      "supavisor",
+							Image:     image,
+							Resources: resources,
+							Env:       env,
+							Command: []string{
+								"/bin/sh",
+								"-c",
+								// $$ escapes Kubernetes variable expansion.
+								`/app/bin/migrate && /app/bin/supavisor eval "$$(cat /etc/pooler/pooler.exs)" && /app/bin/server`,
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "api",
+									ContainerPort: poolerAPIPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          "session",
+									ContainerPort: poolerSessionPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+								{
+									Name:          "transaction",
+									ContainerPort: poolerTransactionPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "pooler-config",
+									MountPath: "/etc/pooler",
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "pooler-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: project.Name + "-pooler-config",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if len(config.ExtraEnv) > 0 {
+		deployment.Spec.Template.Spec.Containers[0].Env = append(
+			deployment.Spec.Template.Spec.Containers[0].Env,
+			config.ExtraEnv...,
+		)
+	}
+
+	return deployment, nil
+}
+
+func (b *SupavisorBuilder) BuildService(project *v1alpha1.SupabaseProject) (*corev1.Service, error) {
+	labels := map[string]string{
+		"app.kubernetes.io/name":       "pooler",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "connection-pooler",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-pooler",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Type:     corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "session",
+					Port:       poolerSessionPort,
+					TargetPort: intstr.FromInt(poolerSessionPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+				{
+					Name:       "transaction",
+					Port:       poolerTransactionPort,
+					TargetPort: intstr.FromInt(poolerTransactionPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+				{
+					Name:       "api",
+					Port:       poolerAPIPort,
+					TargetPort: intstr.FromInt(poolerAPIPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+		},
+	}, nil
+}
+
+func getPoolerDefaultResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+		},
+	}
+}
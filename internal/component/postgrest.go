@@ -1,6 +1,10 @@
 package component
 
 import (
+	"slices"
+	"strconv"
+	"strings"
+
 	"github.com/strrl/supabase-operator/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -9,7 +13,11 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-type PostgRESTBuilder struct{}
+// PostgRESTBuilder builds PostgREST, which verifies tokens against the JWKS
+// in <project>-jwt. JWTChecksum rolls the pods when that JWKS changes.
+type PostgRESTBuilder struct {
+	JWTChecksum string
+}
 
 var _ ComponentBuilder = (*PostgRESTBuilder)(nil)
 
@@ -47,6 +55,16 @@ func (b *PostgRESTBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*
 		sslMode = defaultSSLMode
 	}
 
+	extraSearchPath := []string{"public"}
+	if project.Spec.PostgREST != nil && len(project.Spec.PostgREST.ExtraSearchPath) > 0 {
+		extraSearchPath = project.Spec.PostgREST.ExtraSearchPath
+	}
+
+	dbURI := "postgres://authenticator:$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)?sslmode=" + sslMode
+	if poolerRoutes(project, "postgrest") {
+		dbURI = poolerDatabaseURL(project, "authenticator", poolerTransactionPort)
+	}
+
 	env := []corev1.EnvVar{
 		{
 			Name: "DB_HOST",
@@ -94,8 +112,10 @@ func (b *PostgRESTBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*
 		},
 		{
 			Name:  "PGRST_DB_URI",
-			Value: "postgres://authenticator:$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)?sslmode=" + sslMode,
+			Value: dbURI,
 		},
+		// PostgREST accepts a JWKS here, which lets it keep verifying tokens
+		// signed with the previous secret while a rotation's grace period lasts.
 		{
 			Name: "PGRST_JWT_SECRET",
 			ValueFrom: &corev1.EnvVarSource{
@@ -103,28 +123,54 @@ func (b *PostgRESTBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*
 					LocalObjectReference: corev1.LocalObjectReference{
 						Name: project.Name + "-jwt",
 					},
-					Key: "jwt-secret",
+					Key: JWTJWKSKey,
 				},
 			},
 		},
-		{
-			Name:  "PGRST_JWT_SECRET_IS_BASE64",
-			Value: "true",
-		},
 		{
 			Name:  "PGRST_DB_ANON_ROLE",
 			Value: "anon",
 		},
 		{
 			Name:  "PGRST_DB_SCHEMAS",
-			Value: "public",
+			Value: strings.Join(postgrestExposedSchemas(project), ","),
 		},
 		{
 			Name:  "PGRST_DB_EXTRA_SEARCH_PATH",
-			Value: "public",
+			Value: strings.Join(extraSearchPath, ","),
+		},
+		{
+			Name:  "PGRST_DB_POOL",
+			Value: strconv.Itoa(int(postgrestDBPool(project, replicas))),
 		},
 	}
 
+	if poolerRoutes(project, "postgrest") {
+		// Transaction pooling does not keep prepared statements across requests.
+		env = append(env, corev1.EnvVar{Name: "PGRST_DB_PREPARED_STATEMENTS", Value: "false"})
+	}
+
+	if config := project.Spec.PostgREST; config != nil {
+		if config.MaxRows > 0 {
+			env = append(env, corev1.EnvVar{Name: "PGRST_DB_MAX_ROWS", Value: strconv.Itoa(int(config.MaxRows))})
+		}
+		if config.DBPoolAcquisitionTimeout > 0 {
+			env = append(env, corev1.EnvVar{
+				Name:  "PGRST_DB_POOL_ACQUISITION_TIMEOUT",
+				Value: strconv.Itoa(int(config.DBPoolAcquisitionTimeout)),
+			})
+		}
+		if config.PreRequest != "" {
+			env = append(env, corev1.EnvVar{Name: "PGRST_DB_PRE_REQUEST", Value: config.PreRequest})
+		}
+		if config.OpenAPIMode != "" {
+			env = append(env, corev1.EnvVar{Name: "PGRST_OPENAPI_MODE", Value: config.OpenAPIMode})
+		}
+		if config.ServerTimingEnabled {
+			env = append(env, corev1.EnvVar{Name: "PGRST_SERVER_TIMING_ENABLED", Value: "true"})
+		}
+	}
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      project.Name + "-postgrest",
@@ -138,7 +184,8 @@ func (b *PostgRESTBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: jwtPodAnnotations(b.JWTChecksum),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -201,6 +248,46 @@ func (b *PostgRESTBuilder) BuildService(project *v1alpha1.SupabaseProject) (*cor
 	}, nil
 }
 
+// postgrestExposedSchemas returns the schemas served by PostgREST.
+func postgrestExposedSchemas(project *v1alpha1.SupabaseProject) []string {
+	if project.Spec.PostgREST != nil && len(project.Spec.PostgREST.ExposedSchemas) > 0 {
+		return project.Spec.PostgREST.ExposedSchemas
+	}
+	return []string{"public"}
+}
+
+// postgrestGraphQLDisabled reports whether exposedSchemas is set and leaves
+// out graphql_public. The GraphQL route is kept when exposedSchemas is not
+// set, as it was before the field existed.
+func postgrestGraphQLDisabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.PostgREST != nil && len(project.Spec.PostgREST.ExposedSchemas) > 0 &&
+		!slices.Contains(project.Spec.PostgREST.ExposedSchemas, "graphql_public")
+}
+
+// postgrestDBPool returns the per-replica pool size, capped so that all
+// replicas together stay within database.maxConnections.
+func postgrestDBPool(project *v1alpha1.SupabaseProject, replicas int32) int32 {
+	pool := int32(10)
+	if project.Spec.PostgREST != nil && project.Spec.PostgREST.DBPool > 0 {
+		pool = project.Spec.PostgREST.DBPool
+	}
+
+	if maxConnections := int32(project.Spec.Database.MaxConnections); maxConnections > 0 {
+		if replicas < 1 {
+			replicas = 1
+		}
+		limit := maxConnections / replicas
+		if limit < 1 {
+			limit = 1
+		}
+		if pool > limit {
+			pool = limit
+		}
+	}
+
+	return pool
+}
+
 func getPostgRESTDefaultResources() corev1.ResourceRequirements {
 	return corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
@@ -11,7 +11,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-type RealtimeBuilder struct{}
+// RealtimeBuilder builds Realtime. JWTChecksum is stamped on the pod template.
+type RealtimeBuilder struct {
+	JWTChecksum string
+}
 
 var _ ComponentBuilder = (*RealtimeBuilder)(nil)
 
@@ -114,8 +117,15 @@ func (b *RealtimeBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*a
 			Value: "SET search_path TO _realtime",
 		},
 		{
-			Name:  "DB_ENC_KEY",
-			Value: "supabaserealtime",
+			Name: "DB_ENC_KEY",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: project.Name + "-jwt",
+					},
+					Key: "realtime-db-enc-key",
+				},
+			},
 		},
 		{
 			Name: "API_JWT_SECRET",
@@ -146,7 +156,7 @@ func (b *RealtimeBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*a
 					LocalObjectReference: corev1.LocalObjectReference{
 						Name: project.Name + "-jwt",
 					},
-					Key: "jwt-secret",
+					Key: "realtime-secret-key-base",
 				},
 			},
 		},
@@ -190,6 +200,12 @@ func (b *RealtimeBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*a
 		},
 	}
 
+	if AsymmetricJWTEnabled(project) {
+		// Realtime stores the JWKS on the tenant it seeds, so it accepts user
+		// tokens signed by Auth's asymmetric key next to the HS256 API keys.
+		env = append(env, secretKeyEnvVar("API_JWT_JWKS", project.Name+"-jwt", JWTJWKSKey))
+	}
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      project.Name + "-realtime",
@@ -203,7 +219,8 @@ func (b *RealtimeBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*a
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: jwtPodAnnotations(b.JWTChecksum),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
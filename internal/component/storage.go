@@ -1,6 +1,8 @@
 package component
 
 import (
+	"strconv"
+
 	"github.com/strrl/supabase-operator/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -9,7 +11,77 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-type StorageBuilder struct{}
+// fileStorageBackendPath is where the file backend keeps objects, matching the
+// upstream compose file.
+const fileStorageBackendPath = "/var/lib/storage"
+
+// StorageFileBackend reports whether Storage keeps objects on a PVC instead of S3.
+func StorageFileBackend(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Storage.Backend == "file"
+}
+
+// storageSharedVolume reports whether the file backend claim can be mounted
+// from several nodes at once.
+func storageSharedVolume(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Storage.File != nil && project.Spec.Storage.File.AccessMode == corev1.ReadWriteMany
+}
+
+// BuildStoragePVC creates the claim that backs the file storage backend.
+func BuildStoragePVC(project *v1alpha1.SupabaseProject) *corev1.PersistentVolumeClaim {
+	size := resource.MustParse("10Gi")
+	accessMode := corev1.ReadWriteOnce
+	var storageClassName *string
+	if config := project.Spec.Storage.File; config != nil {
+		if config.Size != nil {
+			size = *config.Size
+		}
+		if config.AccessMode != "" {
+			accessMode = config.AccessMode
+		}
+		storageClassName = config.StorageClassName
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-storage-data",
+			Namespace: project.Namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "storage",
+				"app.kubernetes.io/instance":   project.Name,
+				"app.kubernetes.io/component":  "storage-api",
+				"app.kubernetes.io/part-of":    "supabase",
+				"app.kubernetes.io/managed-by": "supabase-operator",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{accessMode},
+			StorageClassName: storageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+}
+
+// storageDataVolume mounts the file backend claim.
+func storageDataVolume(project *v1alpha1.SupabaseProject) corev1.Volume {
+	return corev1.Volume{
+		Name: "storage-data",
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: project.Name + "-storage-data",
+			},
+		},
+	}
+}
+
+// StorageBuilder builds the Storage API. JWTChecksum is stamped on the pod
+// template.
+type StorageBuilder struct {
+	JWTChecksum string
+}
 
 var _ ComponentBuilder = (*StorageBuilder)(nil)
 
@@ -47,6 +119,27 @@ func (b *StorageBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*ap
 		sslMode = defaultSSLMode
 	}
 
+	forcePathStyle := true
+	if project.Spec.Storage.ForcePathStyle != nil {
+		forcePathStyle = *project.Spec.Storage.ForcePathStyle
+	}
+
+	fileSizeLimit := int64(52428800)
+	tenantID := "stub"
+	if config := project.Spec.StorageAPI; config != nil {
+		if config.FileSizeLimit > 0 {
+			fileSizeLimit = config.FileSizeLimit
+		}
+		if config.TenantID != "" {
+			tenantID = config.TenantID
+		}
+	}
+
+	dbURL := "postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)"
+	if poolerRoutes(project, "storage") {
+		dbURL = poolerDatabaseURL(project, "$(DB_USER)", poolerSessionPort)
+	}
+
 	env := []corev1.EnvVar{
 		{
 			Name: "ANON_KEY",
@@ -142,87 +235,143 @@ func (b *StorageBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*ap
 		},
 		{
 			Name:  "DATABASE_URL",
-			Value: "postgres://$(DB_USER):$(DB_PASSWORD)@$(DB_HOST):$(DB_PORT)/$(DB_NAME)",
+			Value: dbURL,
 		},
 		{
 			Name:  "FILE_SIZE_LIMIT",
-			Value: "52428800",
+			Value: strconv.FormatInt(fileSizeLimit, 10),
 		},
 		{
-			Name:  "STORAGE_BACKEND",
-			Value: "s3",
+			Name:  "TENANT_ID",
+			Value: tenantID,
 		},
 		{
-			Name: "GLOBAL_S3_BUCKET",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: project.Spec.Storage.SecretRef.Name,
+			Name:  "POSTGREST_URL",
+			Value: "http://" + project.Name + "-postgrest:3000",
+		},
+	}
+
+	if StorageFileBackend(project) {
+		env = append(env,
+			corev1.EnvVar{Name: "STORAGE_BACKEND", Value: "file"},
+			corev1.EnvVar{Name: "FILE_STORAGE_BACKEND_PATH", Value: fileStorageBackendPath},
+			corev1.EnvVar{Name: "GLOBAL_S3_BUCKET", Value: "stub"},
+			corev1.EnvVar{Name: "REGION", Value: "local"},
+		)
+	} else {
+		env = append(env,
+			corev1.EnvVar{
+				Name:  "STORAGE_BACKEND",
+				Value: "s3",
+			},
+			corev1.EnvVar{
+				Name: "GLOBAL_S3_BUCKET",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: project.Spec.Storage.SecretRef.Name,
+						},
+						Key: "bucket",
 					},
-					Key: "bucket",
 				},
 			},
-		},
-		{
-			Name: "AWS_ACCESS_KEY_ID",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: project.Spec.Storage.SecretRef.Name,
+			corev1.EnvVar{
+				Name: "AWS_ACCESS_KEY_ID",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: project.Spec.Storage.SecretRef.Name,
+						},
+						Key: "accessKeyId",
 					},
-					Key: "accessKeyId",
 				},
 			},
-		},
-		{
-			Name: "AWS_SECRET_ACCESS_KEY",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: project.Spec.Storage.SecretRef.Name,
+			corev1.EnvVar{
+				Name: "AWS_SECRET_ACCESS_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: project.Spec.Storage.SecretRef.Name,
+						},
+						Key: "secretAccessKey",
 					},
-					Key: "secretAccessKey",
 				},
 			},
-		},
-		{
-			Name: "AWS_DEFAULT_REGION",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: project.Spec.Storage.SecretRef.Name,
+			corev1.EnvVar{
+				Name: "AWS_DEFAULT_REGION",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: project.Spec.Storage.SecretRef.Name,
+						},
+						Key: "region",
 					},
-					Key: "region",
 				},
 			},
-		},
-		{
-			Name: "GLOBAL_S3_ENDPOINT",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: project.Spec.Storage.SecretRef.Name,
+			corev1.EnvVar{
+				Name: "GLOBAL_S3_ENDPOINT",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: project.Spec.Storage.SecretRef.Name,
+						},
+						Key: "endpoint",
 					},
-					Key: "endpoint",
 				},
 			},
-		},
-		{
-			Name:  "GLOBAL_S3_FORCE_PATH_STYLE",
-			Value: "true",
-		},
-		{
-			Name:  "TENANT_ID",
-			Value: "stub",
-		},
-		{
-			Name:  "REGION",
-			Value: "stub",
-		},
-		{
-			Name:  "POSTGREST_URL",
-			Value: "http://" + project.Name + "-postgrest:3000",
-		},
+			corev1.EnvVar{
+				Name:  "GLOBAL_S3_FORCE_PATH_STYLE",
+				Value: strconv.FormatBool(forcePathStyle),
+			},
+			secretKeyEnvVar("REGION", project.Spec.Storage.SecretRef.Name, "region"),
+		)
+	}
+
+	if AsymmetricJWTEnabled(project) {
+		// User tokens are signed by Auth's asymmetric key, API keys by the
+		// JWT secret; the JWKS holds both.
+		env = append(env, secretKeyEnvVar("JWT_JWKS", project.Name+"-jwt", JWTJWKSKey))
+	}
+
+	if ImgproxyEnabled(project) {
+		env = append(env,
+			corev1.EnvVar{Name: "ENABLE_IMAGE_TRANSFORMATION", Value: "true"},
+			corev1.EnvVar{Name: "IMGPROXY_URL", Value: ImgproxyURL(project)},
+		)
+	}
+
+	if config := project.Spec.StorageAPI; config != nil {
+		if config.UploadSignedURLExpiry > 0 {
+			env = append(env, corev1.EnvVar{
+				Name:  "UPLOAD_SIGNED_URL_EXPIRATION_TIME",
+				Value: strconv.Itoa(int(config.UploadSignedURLExpiry)),
+			})
+		}
+
+		if tus := config.TUS; tus != nil {
+			if tus.URLExpirySeconds > 0 {
+				env = append(env, corev1.EnvVar{
+					Name:  "TUS_URL_EXPIRY_MS",
+					Value: strconv.FormatInt(int64(tus.URLExpirySeconds)*1000, 10),
+				})
+			}
+			if tus.PartSizeMB > 0 {
+				env = append(env, corev1.EnvVar{Name: "TUS_PART_SIZE", Value: strconv.Itoa(int(tus.PartSizeMB))})
+			}
+			if tus.MaxConcurrentUploads > 0 {
+				env = append(env, corev1.EnvVar{
+					Name:  "TUS_MAX_CONCURRENT_UPLOADS",
+					Value: strconv.Itoa(int(tus.MaxConcurrentUploads)),
+				})
+			}
+		}
+
+		if ref := config.S3ProtocolSecretRef; ref != nil {
+			env = append(env,
+				secretKeyEnvVar("S3_PROTOCOL_ACCESS_KEY_ID", ref.Name, "accessKeyId"),
+				secretKeyEnvVar("S3_PROTOCOL_ACCESS_KEY_SECRET", ref.Name, "secretAccessKey"),
+			)
+		}
 	}
 
 	deployment := &appsv1.Deployment{
@@ -238,7 +387,8 @@ func (b *StorageBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*ap
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: jwtPodAnnotations(b.JWTChecksum),
 				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
@@ -261,6 +411,20 @@ func (b *StorageBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*ap
 		},
 	}
 
+	if StorageFileBackend(project) {
+		podSpec := &deployment.Spec.Template.Spec
+		podSpec.Volumes = append(podSpec.Volumes, storageDataVolume(project))
+		podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "storage-data",
+			MountPath: fileStorageBackendPath,
+		})
+		// A ReadWriteOnce claim cannot follow a new pod to another node while
+		// the old one still holds it.
+		if !storageSharedVolume(project) {
+			deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+		}
+	}
+
 	if project.Spec.StorageAPI != nil && len(project.Spec.StorageAPI.ExtraEnv) > 0 {
 		deployment.Spec.Template.Spec.Containers[0].Env = append(
 			deployment.Spec.Template.Spec.Containers[0].Env,
@@ -2,6 +2,7 @@ package component
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/strrl/supabase-operator/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -11,7 +12,10 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
-type StudioBuilder struct{}
+// StudioBuilder builds Studio. JWTChecksum is stamped on the pod template.
+type StudioBuilder struct {
+	JWTChecksum string
+}
 
 var _ ComponentBuilder = (*StudioBuilder)(nil)
 
@@ -45,12 +49,18 @@ func (b *StudioBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*app
 
 	apiURL := fmt.Sprintf("http://%s-kong:8000", project.Name)
 	publicURL := apiURL
+	if ingressURL := IngressURL(project); ingressURL != "" {
+		publicURL = ingressURL
+	}
 	if project.Spec.Studio != nil && project.Spec.Studio.PublicURL != "" {
 		publicURL = project.Spec.Studio.PublicURL
 	}
 
 	metaURL := fmt.Sprintf("http://%s-meta:8080", project.Name)
 
+	// The log explorer queries Logflare, so it only works with analytics.
+	logsEnabled := AnalyticsEnabled(project)
+
 	env := []corev1.EnvVar{
 		{Name: "PORT", Value: "3000"},
 		{Name: "HOSTNAME", Value: "0.0.0.0"},
@@ -60,9 +70,9 @@ func (b *StudioBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*app
 		{Name: "NEXT_PUBLIC_GOTRUE_URL", Value: fmt.Sprintf("%s/auth/v1", publicURL)},
 		{Name: "NEXT_PUBLIC_SITE_URL", Value: publicURL},
 		{Name: "STUDIO_PG_META_URL", Value: metaURL},
-		{Name: "NEXT_PUBLIC_ENABLE_LOGS", Value: "false"},
+		{Name: "NEXT_PUBLIC_ENABLE_LOGS", Value: strconv.FormatBool(logsEnabled)},
 		{Name: "NEXT_ANALYTICS_BACKEND_PROVIDER", Value: "postgres"},
-		{Name: "ENABLED_FEATURES_LOGS_ALL", Value: "false"},
+		{Name: "ENABLED_FEATURES_LOGS_ALL", Value: strconv.FormatBool(logsEnabled)},
 		{
 			Name: "POSTGRES_USER_READ_WRITE",
 			ValueFrom: &corev1.EnvVarSource{
@@ -155,6 +165,15 @@ func (b *StudioBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*app
 		},
 	}
 
+	if logsEnabled {
+		analyticsSecretName := project.Name + "-analytics"
+		env = append(env,
+			corev1.EnvVar{Name: "LOGFLARE_URL", Value: AnalyticsURL(project)},
+			secretKeyEnvVar("LOGFLARE_PUBLIC_ACCESS_TOKEN", analyticsSecretName, AnalyticsPublicAccessToken),
+			secretKeyEnvVar("LOGFLARE_PRIVATE_ACCESS_TOKEN", analyticsSecretName, AnalyticsPrivateAccessToken),
+		)
+	}
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      project.Name + "-studio",
@@ -165,7 +184,10 @@ func (b *StudioBuilder) BuildDeployment(project *v1alpha1.SupabaseProject) (*app
 			Replicas: &replicas,
 			Selector: &metav1.LabelSelector{MatchLabels: labels},
 			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: jwtPodAnnotations(b.JWTChecksum),
+				},
 				Spec: corev1.PodSpec{
 					Containers: []corev1.Container{
 						{
@@ -0,0 +1,378 @@
+package component
+
+import (
+	"strings"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// vectorPodLogsPath is where the kubelet writes container logs on each node.
+const vectorPodLogsPath = "/var/log/pods"
+
+// vectorConfigTemplate mirrors upstream supabase docker/volumes/logs/vector.yml.
+// Instead of the docker_logs source it tails the CRI log files of the project's
+// pods from the node, so Vector needs no access to the Kubernetes API.
+// {{NAMESPACE}}, {{PROJECT}} and {{ANALYTICS_URL}} are replaced when the
+// ConfigMap is built.
+const vectorConfigTemplate = `api:
+  enabled: true
+  address: 0.0.0.0:9001
+
+data_dir: /var/lib/vector
+
+sources:
+  project_pods:
+    type: file
+    include:
+      - /var/log/pods/{{NAMESPACE}}_{{PROJECT}}-kong-*/*/*.log
+      - /var/log/pods/{{NAMESPACE}}_{{PROJECT}}-auth-*/*/*.log
+      - /var/log/pods/{{NAMESPACE}}_{{PROJECT}}-postgrest-*/*/*.log
+      - /var/log/pods/{{NAMESPACE}}_{{PROJECT}}-realtime-*/*/*.log
+      - /var/log/pods/{{NAMESPACE}}_{{PROJECT}}-storage-*/*/*.log
+      - /var/log/pods/{{NAMESPACE}}_{{PROJECT}}-functions-*/*/*.log
+    ignore_older_secs: 600
+
+transforms:
+  project_logs:
+    type: remap
+    inputs:
+      - project_pods
+    source: |-
+      line, err = parse_regex(.message, r'^(?P<time>\S+) (?P<stream>stdout|stderr) [FP] (?P<msg>.*)$')
+      if err == null {
+        .timestamp = parse_timestamp(line.time, "%+") ?? now()
+        .event_message = line.msg
+      } else {
+        .event_message = .message
+      }
+      pod, err = parse_regex(.file, r'^/var/log/pods/[^_]+_{{PROJECT}}-(?P<appname>[a-z]+)-')
+      if err == null {
+        .appname = pod.appname
+      }
+      .project = "default"
+      del(.message)
+      del(.file)
+      del(.host)
+      del(.source_type)
+  router:
+    type: route
+    inputs:
+      - project_logs
+    route:
+      kong: '.appname == "kong"'
+      auth: '.appname == "auth"'
+      rest: '.appname == "postgrest"'
+      realtime: '.appname == "realtime"'
+      storage: '.appname == "storage"'
+      functions: '.appname == "functions"'
+  kong_logs:
+    type: remap
+    inputs:
+      - router.kong
+    source: |-
+      req, err = parse_nginx_log(.event_message, "combined")
+      if err == null {
+        .timestamp = req.timestamp
+        .metadata.request.headers.referer = req.referer
+        .metadata.request.headers.user_agent = req.agent
+        .metadata.request.headers.cf_connecting_ip = req.client
+        .metadata.request.method = req.method
+        .metadata.request.path = req.path
+        .metadata.request.protocol = req.protocol
+        .metadata.response.status_code = req.status
+      }
+      if err != null {
+        abort
+      }
+  auth_logs:
+    type: remap
+    inputs:
+      - router.auth
+    source: |-
+      parsed, err = parse_json(.event_message)
+      if err == null {
+        .metadata.timestamp = parsed.time
+        .metadata = merge!(.metadata, parsed)
+      }
+  rest_logs:
+    type: remap
+    inputs:
+      - router.rest
+    source: |-
+      parsed, err = parse_regex(.event_message, r'^(?P<time>.*): (?P<msg>.*)$')
+      if err == null {
+        .event_message = parsed.msg
+        .timestamp = parse_timestamp(parsed.time, "%d/%b/%Y:%H:%M:%S %z") ?? .timestamp
+        .metadata.host = .project
+      }
+  realtime_logs:
+    type: remap
+    inputs:
+      - router.realtime
+    source: |-
+      .metadata.project = del(.project)
+      .metadata.external_id = .metadata.project
+      parsed, err = parse_regex(.event_message, r'^(?P<time>\d+:\d+:\d+\.\d+) \[(?P<level>\w+)\] (?P<msg>.*)$')
+      if err == null {
+        .event_message = parsed.msg
+        .metadata.level = parsed.level
+      }
+  storage_logs:
+    type: remap
+    inputs:
+      - router.storage
+    source: |-
+      .metadata.project = del(.project)
+      .metadata.tenantId = .metadata.project
+      parsed, err = parse_json(.event_message)
+      if err == null {
+        .event_message = parsed.msg
+        .metadata.level = parsed.level
+        .metadata.timestamp = parsed.time
+        .metadata.context[0].host = parsed.hostname
+        .metadata.context[0].pid = parsed.pid
+      }
+
+sinks:
+  logflare_auth:
+    type: http
+    inputs:
+      - auth_logs
+    encoding:
+      codec: json
+    method: post
+    request:
+      retry_max_duration_secs: 10
+      headers:
+        x-api-key: ${LOGFLARE_PUBLIC_ACCESS_TOKEN}
+    uri: {{ANALYTICS_URL}}/api/logs?source_name=gotrue.logs.prod
+  logflare_realtime:
+    type: http
+    inputs:
+      - realtime_logs
+    encoding:
+      codec: json
+    method: post
+    request:
+      retry_max_duration_secs: 10
+      headers:
+        x-api-key: ${LOGFLARE_PUBLIC_ACCESS_TOKEN}
+    uri: {{ANALYTICS_URL}}/api/logs?source_name=realtime.logs.prod
+  logflare_rest:
+    type: http
+    inputs:
+      - rest_logs
+    encoding:
+      codec: json
+    method: post
+    request:
+      retry_max_duration_secs: 10
+      headers:
+        x-api-key: ${LOGFLARE_PUBLIC_ACCESS_TOKEN}
+    uri: {{ANALYTICS_URL}}/api/logs?source_name=postgREST.logs.prod
+  logflare_storage:
+    type: http
+    inputs:
+      - storage_logs
+    encoding:
+      codec: json
+    method: post
+    request:
+      retry_max_duration_secs: 10
+      headers:
+        x-api-key: ${LOGFLARE_PUBLIC_ACCESS_TOKEN}
+    uri: {{ANALYTICS_URL}}/api/logs?source_name=storage.logs.prod.2
+  logflare_functions:
+    type: http
+    inputs:
+      - router.functions
+    encoding:
+      codec: json
+    method: post
+    request:
+      retry_max_duration_secs: 10
+      headers:
+        x-api-key: ${LOGFLARE_PUBLIC_ACCESS_TOKEN}
+    uri: {{ANALYTICS_URL}}/api/logs?source_name=deno-relay-logs
+  logflare_kong:
+    type: http
+    inputs:
+      - kong_logs
+    encoding:
+      codec: json
+    method: post
+    request:
+      retry_max_duration_secs: 10
+      headers:
+        x-api-key: ${LOGFLARE_PUBLIC_ACCESS_TOKEN}
+    uri: {{ANALYTICS_URL}}/api/logs?source_name=cloudflare.logs.prod
+`
+
+// VectorEnabled reports whether the log pipeline should run. Vector has
+// nowhere to ship logs without analytics, so both must be enabled.
+func VectorEnabled(project *v1alpha1.SupabaseProject) bool {
+	return project.Spec.Vector != nil && project.Spec.Vector.Enabled && AnalyticsEnabled(project)
+}
+
+func vectorLabels(project *v1alpha1.SupabaseProject) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       "vector",
+		"app.kubernetes.io/instance":   project.Name,
+		"app.kubernetes.io/component":  "log-pipeline",
+		"app.kubernetes.io/part-of":    "supabase",
+		"app.kubernetes.io/managed-by": "supabase-operator",
+	}
+}
+
+// BuildVectorConfigMap renders the Vector pipeline for the project.
+func BuildVectorConfigMap(project *v1alpha1.SupabaseProject) *corev1.ConfigMap {
+	config := strings.NewReplacer(
+		"{{NAMESPACE}}", project.Namespace,
+		"{{PROJECT}}", project.Name,
+		"{{ANALYTICS_URL}}", AnalyticsURL(project),
+	).Replace(vectorConfigTemplate)
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-vector-config",
+			Namespace: project.Namespace,
+			Labels:    vectorLabels(project),
+		},
+		Data: map[string]string{
+			"vector.yml": config,
+		},
+	}
+}
+
+// BuildVectorDaemonSet runs Vector on every node so that it can read the log
+// files of the project's pods wherever they are scheduled.
+func BuildVectorDaemonSet(project *v1alpha1.SupabaseProject) *appsv1.DaemonSet {
+	image := v1alpha1.DefaultVectorImage
+	if project.Spec.Vector != nil && project.Spec.Vector.Image != "" {
+		image = project.Spec.Vector.Image
+	}
+
+	resources := getVectorDefaultResources()
+	if project.Spec.Vector != nil && project.Spec.Vector.Resources != nil {
+		resources = *project.Spec.Vector.Resources
+	}
+
+	var tolerations []corev1.Toleration
+	if project.Spec.Vector != nil {
+		tolerations = project.Spec.Vector.Tolerations
+	}
+
+	labels := vectorLabels(project)
+
+	env := []corev1.EnvVar{
+		secretKeyEnvVar("LOGFLARE_PUBLIC_ACCESS_TOKEN", project.Name+"-analytics", AnalyticsPublicAccessToken),
+	}
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      project.Name + "-vector",
+			Namespace: project.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Tolerations: tolerations,
+					Containers: []corev1.Container{
+						{
+							Name:      "vector",
+							Image:     image,
+							Resources: resources,
+							Env:       env,
+							Args: []string{
+								"--config", "/etc/vector/vector.yml",
+								"--watch-config",
+							},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "api",
+									ContainerPort: 9001,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "vector-config",
+									MountPath: "/etc/vector",
+									ReadOnly:  true,
+								},
+								{
+									Name:      "pod-logs",
+									MountPath: vectorPodLogsPath,
+									ReadOnly:  true,
+								},
+								{
+									Name:      "data",
+									MountPath: "/var/lib/vector",
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "vector-config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: project.Name + "-vector-config",
+									},
+								},
+							},
+						},
+						{
+							Name: "pod-logs",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{
+									Path: vectorPodLogsPath,
+								},
+							},
+						},
+						{
+							Name: "data",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if project.Spec.Vector != nil && len(project.Spec.Vector.ExtraEnv) > 0 {
+		daemonSet.Spec.Template.Spec.Containers[0].Env = append(
+			daemonSet.Spec.Template.Spec.Containers[0].Env,
+			project.Spec.Vector.ExtraEnv...,
+		)
+	}
+
+	return daemonSet
+}
+
+func getVectorDefaultResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+			corev1.ResourceCPU:    resource.MustParse("200m"),
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/component"
+	"github.com/strrl/supabase-operator/internal/secrets"
+)
+
+// defaultAPIKeyRenewBefore applies when spec.jwt does not set
+// apiKeyRenewBeforeSeconds.
+const defaultAPIKeyRenewBefore = 30 * 24 * time.Hour
+
+// jwtAPIKeys are the keys of the <project>-jwt Secret holding JWT API keys,
+// with the role each one is minted for and the key Kong reads the previous
+// value from.
+var jwtAPIKeys = []struct {
+	key      string
+	role     string
+	previous string
+}{
+	{anonKeyKey, "anon", component.JWTPreviousAnonKey},
+	{serviceRoleKey, "service_role", component.JWTPreviousServiceRoleKey},
+}
+
+func apiKeyTTL(project *supabasev1alpha1.SupabaseProject) time.Duration {
+	if project.Spec.JWT == nil || project.Spec.JWT.APIKeyTTL == nil {
+		return secrets.DefaultAPIKeyTTL
+	}
+	return time.Duration(*project.Spec.JWT.APIKeyTTL) * time.Second
+}
+
+func apiKeyRenewBefore(project *supabasev1alpha1.SupabaseProject) time.Duration {
+	if project.Spec.JWT == nil || project.Spec.JWT.APIKeyRenewBeforeSeconds == nil {
+		return defaultAPIKeyRenewBefore
+	}
+	return time.Duration(*project.Spec.JWT.APIKeyRenewBeforeSeconds) * time.Second
+}
+
+// apiKeyNeedsRenewal reports whether key is about to expire or outlives the
+// configured TTL. Keys without an exp claim are left alone.
+func apiKeyNeedsRenewal(project *supabasev1alpha1.SupabaseProject, key string, now time.Time) bool {
+	expiry, err := secrets.APIKeyExpiry(key)
+	if err != nil || expiry.IsZero() {
+		return false
+	}
+	return expiry.Sub(now) < apiKeyRenewBefore(project) || expiry.After(now.Add(apiKeyTTL(project)))
+}
+
+// apiKeyRenewalRemaining returns how long until the first API key of the
+// <project>-jwt Secret is due for renewal, or zero when none expires.
+func apiKeyRenewalRemaining(project *supabasev1alpha1.SupabaseProject, secret *corev1.Secret, now time.Time) time.Duration {
+	var remaining time.Duration
+	for _, k := range jwtAPIKeys {
+		expiry, err := secrets.APIKeyExpiry(string(secret.Data[k.key]))
+		if err != nil || expiry.IsZero() {
+			continue
+		}
+		until := expiry.Add(-apiKeyRenewBefore(project)).Sub(now)
+		if until <= 0 {
+			continue
+		}
+		if remaining == 0 || until < remaining {
+			remaining = until
+		}
+	}
+	return remaining
+}
+
+// reconcileAPIKeyExpiry re-mints the anon and service role keys in the
+// <project>-jwt Secret that are about to expire or outlive the configured TTL.
+// Kong keeps accepting the replaced keys as previous keys until they expire,
+// for at most the renewal threshold. While a grace period is running the
+// previous keys are taken, so re-mints wait for it to end unless the key has
+// already expired. Keys supplied through jwt.secretRef belong to the user and
+// only get a warning. The components roll through the JWT checksum once the
+// Secret changes.
+func (r *SupabaseProjectReconciler) reconcileAPIKeyExpiry(ctx context.Context, project *supabasev1alpha1.SupabaseProject, secret *corev1.Secret) error {
+	supplied := map[string][]byte{}
+	if ref := jwtSecretRef(project); ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = project.Namespace
+		}
+		userSecret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, userSecret); err != nil {
+			return err
+		}
+		supplied = userSecret.Data
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	_, gracePeriodRunning := secret.Annotations[jwtPreviousExpiresAtAnnotation]
+
+	now := time.Now()
+	acceptedUntil := now
+	changed := false
+	for _, k := range jwtAPIKeys {
+		current := string(secret.Data[k.key])
+		if !apiKeyNeedsRenewal(project, current, now) {
+			continue
+		}
+		expiry, _ := secrets.APIKeyExpiry(current)
+
+		if len(supplied[k.key]) > 0 {
+			r.Recorder.Eventf(project, corev1.EventTypeWarning, EventReasonAPIKeyExpiring, EventMessageSuppliedAPIKeyExpiringFmt,
+				k.key, expiry.UTC().Format(time.RFC3339))
+			continue
+		}
+
+		if gracePeriodRunning && expiry.After(now) {
+			continue
+		}
+
+		key, err := secrets.GenerateAPIKey(string(secret.Data[jwtSecretKey]), k.role, apiKeyTTL(project))
+		if err != nil {
+			return err
+		}
+		if expiry.After(now) {
+			secret.Data[k.previous] = []byte(current)
+			until := now.Add(apiKeyRenewBefore(project))
+			if expiry.Before(until) {
+				until = expiry
+			}
+			if until.After(acceptedUntil) {
+				acceptedUntil = until
+			}
+		}
+		secret.Data[k.key] = []byte(key)
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	if acceptedUntil.After(now) {
+		secret.Annotations[jwtPreviousExpiresAtAnnotation] = acceptedUntil.UTC().Format(time.RFC3339)
+	}
+	r.Recorder.Eventf(project, corev1.EventTypeNormal, EventReasonAPIKeysReminted, EventMessageAPIKeysRemintedFmt,
+		acceptedUntil.UTC().Format(time.RFC3339))
+	return r.Update(ctx, secret)
+}
@@ -48,7 +48,8 @@
 //
 // The controller requires permissions defined via kubebuilder markers:
 //   - SupabaseProject: full CRUD + status + finalizers
-//   - Deployments, Services, ConfigMaps, Secrets: full CRUD
+//   - Deployments, DaemonSets, Services, ConfigMaps, Secrets: full CRUD
 //   - Jobs: full CRUD (for database initialization)
+//   - PersistentVolumeClaims: get, list, watch, create (for the file storage backend)
 //   - Events: create, patch (for event recording)
 package controller
@@ -30,6 +30,13 @@ const (
 	EventReasonDatabaseInitFailed       = "DatabaseInitFailed"
 	EventReasonComponentDeploymentReady = "ComponentDeploymentReady"
 	EventReasonReconciliationComplete   = "ReconciliationComplete"
+	EventReasonRealtimeLegacyEncKey     = "RealtimeLegacyEncryptionKey"
+	EventReasonJWTRotated               = "JWTRotated"
+	EventReasonJWTGracePeriodEnded      = "JWTGracePeriodEnded"
+	EventReasonJWTRotationIgnored       = "JWTRotationIgnored"
+	EventReasonAPIKeyExpiring           = "APIKeyExpiring"
+	EventReasonAPIKeysReminted          = "APIKeysReminted"
+	EventReasonAPIKeyIssued             = "APIKeyIssued"
 )
 
 const (
@@ -44,4 +51,11 @@ const (
 	EventMessageDependencyValidationFailedFmt = "Dependency validation failed: %v"
 	EventMessageSecretsFailedFmt              = "Failed to generate JWT secrets: %v"
 	EventMessageDatabaseInitFailedFmt         = "Failed to initialize database: %v"
+	EventMessageRealtimeLegacyEncKey          = "Realtime tenant seeding is disabled, keeping the legacy DB_ENC_KEY in realtime-db-enc-key"
+	EventMessageJWTRotatedFmt                 = "Rotated JWT secret and API keys, the previous ones stay valid for %s"
+	EventMessageJWTGracePeriodEnded           = "Grace period ended, removed the previous JWT secret and API keys"
+	EventMessageJWTRotationIgnored            = "JWT rotation requested, but the JWT secret is supplied through jwt.secretRef"
+	EventMessageAPIKeysRemintedFmt            = "Re-minted API keys that expire soon or outlive jwt.apiKeyTTL, Kong accepts the previous ones until %s"
+	EventMessageSuppliedAPIKeyExpiringFmt     = "%s from jwt.secretRef expires at %s, replace it in the referenced Secret"
+	EventMessageAPIKeyIssuedFmt               = "Signed API key into Secret %s"
 )
@@ -0,0 +1,317 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/component"
+	"github.com/strrl/supabase-operator/internal/secrets"
+	"github.com/strrl/supabase-operator/internal/status"
+)
+
+// Annotations on the <project>-jwt Secret that record the rotation state.
+const (
+	// jwtRotationTriggerAnnotation and jwtRotationRequestAnnotation hold the
+	// spec.jwt.rotation.trigger and supabase.strrl.dev/rotate-jwt values the
+	// last rotation served.
+	jwtRotationTriggerAnnotation = "supabase.strrl.dev/jwt-rotation-trigger"
+	jwtRotationRequestAnnotation = "supabase.strrl.dev/jwt-rotation-request"
+
+	jwtRotatedAtAnnotation         = "supabase.strrl.dev/jwt-rotated-at"
+	jwtPreviousExpiresAtAnnotation = "supabase.strrl.dev/jwt-previous-expires-at"
+)
+
+// defaultJWTGracePeriod applies to rotations requested through the annotation
+// when spec.jwt.rotation does not set a grace period.
+const defaultJWTGracePeriod = 24 * time.Hour
+
+// handledJWTRotationRequests returns the annotations that mark the project's
+// current rotation requests as served.
+func handledJWTRotationRequests(project *supabasev1alpha1.SupabaseProject) map[string]string {
+	annotations := map[string]string{}
+	if trigger := jwtRotationTrigger(project); trigger != "" {
+		annotations[jwtRotationTriggerAnnotation] = trigger
+	}
+	if request := project.Annotations[supabasev1alpha1.RotateJWTAnnotation]; request != "" {
+		annotations[jwtRotationRequestAnnotation] = request
+	}
+	return annotations
+}
+
+func jwtRotationTrigger(project *supabasev1alpha1.SupabaseProject) string {
+	if project.Spec.JWT == nil || project.Spec.JWT.Rotation == nil {
+		return ""
+	}
+	return project.Spec.JWT.Rotation.Trigger
+}
+
+func jwtRotationGracePeriod(project *supabasev1alpha1.SupabaseProject) time.Duration {
+	if project.Spec.JWT == nil || project.Spec.JWT.Rotation == nil || project.Spec.JWT.Rotation.GracePeriodSeconds == nil {
+		return defaultJWTGracePeriod
+	}
+	return time.Duration(*project.Spec.JWT.Rotation.GracePeriodSeconds) * time.Second
+}
+
+// jwtRotationRequested reports whether the trigger or the annotation was set
+// to a value that has not been served yet.
+func jwtRotationRequested(project *supabasev1alpha1.SupabaseProject, secret *corev1.Secret) bool {
+	for key, value := range handledJWTRotationRequests(project) {
+		if secret.Annotations[key] != value {
+			return true
+		}
+	}
+	return false
+}
+
+// hmacJWTKeys returns the current and, if present, the previous JWT secret as
+// JWKs.
+func hmacJWTKeys(data map[string][]byte) ([]secrets.JWK, error) {
+	var keys []secrets.JWK
+	for _, secretKey := range []string{jwtSecretKey, component.JWTPreviousSecretKey} {
+		jwtSecret, ok := data[secretKey]
+		if !ok {
+			continue
+		}
+		key, err := secrets.HMACJWK(string(jwtSecret))
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// derivedJWTKeys returns the values computed from the JWT secrets and the
+// signing key: the JWKS PostgREST and Storage verify tokens with and, when
+// Auth signs with an asymmetric key, the GOTRUE_JWT_KEYS value.
+func derivedJWTKeys(data map[string][]byte) (map[string]string, error) {
+	keys, err := hmacJWTKeys(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JWKS: %w", err)
+	}
+
+	derived := map[string]string{}
+	if encoded := data[component.JWTSigningKeyKey]; len(encoded) > 0 {
+		signingKey, err := secrets.ParseJWK(string(encoded))
+		if err != nil {
+			return nil, err
+		}
+
+		// Auth signs with the key that allows it and verifies the HS256 API
+		// keys with the others.
+		authKeys, err := json.Marshal(append([]secrets.JWK{signingKey}, keys...))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode auth JWT keys: %w", err)
+		}
+		derived[component.JWTAuthKeysKey] = string(authKeys)
+
+		keys = append([]secrets.JWK{signingKey.Public()}, keys...)
+	}
+
+	jwks, err := secrets.EncodeJWKS(keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JWKS: %w", err)
+	}
+	derived[component.JWTJWKSKey] = jwks
+
+	return derived, nil
+}
+
+// updateDerivedJWTKeys brings the derived keys of the <project>-jwt Secret
+// data up to date and reports whether anything changed.
+func updateDerivedJWTKeys(data map[string][]byte) (bool, error) {
+	derived, err := derivedJWTKeys(data)
+	if err != nil {
+		return false, err
+	}
+
+	changed := false
+	if _, ok := derived[component.JWTAuthKeysKey]; !ok {
+		if _, ok := data[component.JWTAuthKeysKey]; ok {
+			delete(data, component.JWTAuthKeysKey)
+			changed = true
+		}
+	}
+	for key, value := range derived {
+		if string(data[key]) != value {
+			data[key] = []byte(value)
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// jwtGracePeriodRemaining returns how long the previous JWT secret is still
+// accepted, or zero when there is none.
+func jwtGracePeriodRemaining(secret *corev1.Secret, now time.Time) time.Duration {
+	value, ok := secret.Annotations[jwtPreviousExpiresAtAnnotation]
+	if !ok {
+		return 0
+	}
+	expiresAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0
+	}
+	return expiresAt.Sub(now)
+}
+
+// reconcileJWTRotation replaces the generated JWT secret and API keys when a
+// rotation is requested, keeping the previous ones for the grace period, and
+// drops the previous ones once the grace period is over. It returns the
+// up-to-date <project>-jwt Secret.
+func (r *SupabaseProjectReconciler) reconcileJWTRotation(ctx context.Context, project *supabasev1alpha1.SupabaseProject) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: project.Name + "-jwt"}, secret); err != nil {
+		return nil, err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	now := time.Now()
+	previousKeys := map[string]string{
+		component.JWTPreviousSecretKey:      jwtSecretKey,
+		component.JWTPreviousAnonKey:        anonKeyKey,
+		component.JWTPreviousServiceRoleKey: serviceRoleKey,
+	}
+	dropPrevious := func() {
+		for previousKey := range previousKeys {
+			delete(secret.Data, previousKey)
+		}
+		delete(secret.Annotations, jwtPreviousExpiresAtAnnotation)
+	}
+
+	changed := false
+	switch {
+	case jwtRotationRequested(project, secret) && jwtSecretRef(project) != nil:
+		// The referenced Secret is the source of truth; mark the request as
+		// served so that it is reported only once.
+		r.Recorder.Event(project, corev1.EventTypeWarning, EventReasonJWTRotationIgnored, EventMessageJWTRotationIgnored)
+		changed = true
+
+	case jwtRotationRequested(project, secret):
+		keys, err := generateJWTKeys(apiKeyTTL(project))
+		if err != nil {
+			return nil, err
+		}
+
+		gracePeriod := jwtRotationGracePeriod(project)
+		if gracePeriod > 0 {
+			for previousKey, currentKey := range previousKeys {
+				secret.Data[previousKey] = secret.Data[currentKey]
+			}
+			secret.Annotations[jwtPreviousExpiresAtAnnotation] = now.Add(gracePeriod).UTC().Format(time.RFC3339)
+		} else {
+			dropPrevious()
+		}
+
+		for key, value := range keys {
+			secret.Data[key] = []byte(value)
+		}
+		secret.Annotations[jwtRotatedAtAnnotation] = now.UTC().Format(time.RFC3339)
+		r.Recorder.Eventf(project, corev1.EventTypeNormal, EventReasonJWTRotated, EventMessageJWTRotatedFmt, gracePeriod)
+		changed = true
+
+	case secret.Annotations[jwtPreviousExpiresAtAnnotation] != "" && jwtGracePeriodRemaining(secret, now) <= 0:
+		dropPrevious()
+		r.Recorder.Event(project, corev1.EventTypeNormal, EventReasonJWTGracePeriodEnded, EventMessageJWTGracePeriodEnded)
+		changed = true
+	}
+
+	if !changed {
+		return secret, nil
+	}
+
+	for key, value := range handledJWTRotationRequests(project) {
+		secret.Annotations[key] = value
+	}
+
+	if _, err := updateDerivedJWTKeys(secret.Data); err != nil {
+		return nil, err
+	}
+
+	if err := r.Update(ctx, secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// jwtConsumers returns the names of the components that read the
+// <project>-jwt Secret.
+func jwtConsumers(project *supabasev1alpha1.SupabaseProject) []string {
+	consumers := []string{"kong", "auth", "postgrest", "realtime", "storage", "studio"}
+	if component.FunctionsEnabled(project) {
+		consumers = append(consumers, "functions")
+	}
+	if component.PoolerEnabled(project) {
+		consumers = append(consumers, "pooler")
+	}
+	return consumers
+}
+
+// pendingJWTConsumers returns the components whose pods do not all run with
+// the given <project>-jwt checksum yet.
+func (r *SupabaseProjectReconciler) pendingJWTConsumers(ctx context.Context, project *supabasev1alpha1.SupabaseProject, checksum string) ([]string, error) {
+	var pending []string
+	for _, name := range jwtConsumers(project) {
+		deploy := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: project.Name + "-" + name}, deploy); err != nil {
+			if apierrors.IsNotFound(err) {
+				pending = append(pending, name)
+				continue
+			}
+			return nil, err
+		}
+
+		replicas := int32(1)
+		if deploy.Spec.Replicas != nil {
+			replicas = *deploy.Spec.Replicas
+		}
+		rolledOut := deploy.Spec.Template.Annotations[component.JWTChecksumAnnotation] == checksum &&
+			deploy.Status.ObservedGeneration >= deploy.Generation &&
+			deploy.Status.UpdatedReplicas == replicas &&
+			deploy.Status.Replicas == replicas &&
+			deploy.Status.AvailableReplicas == replicas
+		if !rolledOut {
+			pending = append(pending, name)
+		}
+	}
+	return pending, nil
+}
+
+// jwtRotationCondition reports the progress of the last JWT rotation.
+func jwtRotationCondition(project *supabasev1alpha1.SupabaseProject, secret *corev1.Secret, pendingConsumers []string) metav1.Condition {
+	rotatedAt := secret.Annotations[jwtRotatedAtAnnotation]
+	expiresAt := secret.Annotations[jwtPreviousExpiresAtAnnotation]
+
+	switch {
+	case jwtSecretRef(project) != nil && expiresAt == "":
+		return status.NewComponentCondition(status.ConditionTypeJWTRotation, metav1.ConditionFalse, "ExternalSecret",
+			"JWT secret is supplied through jwt.secretRef and is not rotated by the operator")
+	case rotatedAt == "":
+		return status.NewComponentCondition(status.ConditionTypeJWTRotation, metav1.ConditionFalse, "NotRotated",
+			"JWT secret has not been rotated")
+	case len(pendingConsumers) > 0:
+		return status.NewComponentCondition(status.ConditionTypeJWTRotation, metav1.ConditionTrue, "RollingOut",
+			fmt.Sprintf("JWT secret rotated at %s, waiting for %s to roll out", rotatedAt, strings.Join(pendingConsumers, ", ")))
+	case expiresAt != "":
+		return status.NewComponentCondition(status.ConditionTypeJWTRotation, metav1.ConditionTrue, "GracePeriod",
+			fmt.Sprintf("JWT secret rotated at %s, PostgREST and Kong accept the previous secret and API keys until %s", rotatedAt, expiresAt))
+	default:
+		return status.NewComponentCondition(status.ConditionTypeJWTRotation, metav1.ConditionFalse, "Completed",
+			fmt.Sprintf("JWT secret rotated at %s", rotatedAt))
+	}
+}
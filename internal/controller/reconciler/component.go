@@ -8,7 +8,9 @@ import (
 	"github.com/strrl/supabase-operator/internal/component"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -67,6 +69,51 @@ func (r *ComponentReconciler) ReconcileComponent(
 		} else {
 			return err
 		}
+	} else if !equality.Semantic.DeepEqual(existingService.Spec.Ports, service.Spec.Ports) ||
+		!equality.Semantic.DeepEqual(existingService.Spec.Selector, service.Spec.Selector) {
+		// Only the fields the builders own are copied so the allocated
+		// ClusterIP and other defaulted fields are preserved. The builders set
+		// every port field of their ClusterIP Services, so unchanged Services
+		// compare equal and are not written.
+		existingService.Spec.Ports = service.Spec.Ports
+		existingService.Spec.Selector = service.Spec.Selector
+		if err := r.Client.Update(ctx, existingService); err != nil {
+			return fmt.Errorf("failed to update %s service: %w", builder.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// DeleteComponent removes the Deployment and Service of an optional component
+// once it is disabled. Objects not controlled by the project are left alone.
+func (r *ComponentReconciler) DeleteComponent(
+	ctx context.Context,
+	project *supabasev1alpha1.SupabaseProject,
+	builder component.ComponentBuilder,
+) error {
+	deployment, err := builder.BuildDeployment(project)
+	if err != nil {
+		return fmt.Errorf("failed to build %s deployment: %w", builder.Name(), err)
+	}
+	service, err := builder.BuildService(project)
+	if err != nil {
+		return fmt.Errorf("failed to build %s service: %w", builder.Name(), err)
+	}
+
+	for _, obj := range []client.Object{deployment, service} {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(obj, project) {
+			continue
+		}
+		if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %s %s: %w", builder.Name(), obj.GetName(), err)
+		}
 	}
 
 	return nil
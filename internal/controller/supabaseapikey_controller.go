@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/component"
+	"github.com/strrl/supabase-operator/internal/secrets"
+	"github.com/strrl/supabase-operator/internal/status"
+)
+
+// apiKeySpecChecksumAnnotation carries a digest of the spec fields that end up
+// in the token on the SupabaseAPIKey's Secret, so edits sign a new token.
+const apiKeySpecChecksumAnnotation = "supabase.strrl.dev/api-key-spec-checksum"
+
+// SupabaseAPIKeyReconciler signs the token of a SupabaseAPIKey with the JWT
+// secret of its project and writes it to a Secret. The project's reconciler
+// registers ready keys as Kong consumers.
+type SupabaseAPIKeyReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=supabase.strrl.dev,resources=supabaseapikeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=supabase.strrl.dev,resources=supabaseapikeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=supabase.strrl.dev,resources=supabaseapikeys/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+func (r *SupabaseAPIKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	apiKey := &supabasev1alpha1.SupabaseAPIKey{}
+	if err := r.Get(ctx, req.NamespacedName, apiKey); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	// The Secret is owned by the SupabaseAPIKey, so deleting the key removes
+	// it, and the project's reconciler drops the Kong consumer.
+	if apiKey.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	secretName := apiKeySecretName(apiKey)
+	apiKey.Status.SecretName = secretName
+
+	project := &supabasev1alpha1.SupabaseProject{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: apiKey.Namespace, Name: apiKey.Spec.ProjectRef.Name}, project); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.setAPIKeyNotReady(ctx, apiKey, "ProjectNotFound",
+				fmt.Sprintf("SupabaseProject %s not found", apiKey.Spec.ProjectRef.Name))
+		}
+		return ctrl.Result{}, err
+	}
+
+	jwtSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: project.Name + "-jwt"}, jwtSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.setAPIKeyNotReady(ctx, apiKey, "JWTSecretNotReady",
+				fmt.Sprintf("Secret %s-jwt not found", project.Name))
+		}
+		return ctrl.Result{}, err
+	}
+	signingSecret := string(jwtSecret.Data[jwtSecretKey])
+	if signingSecret == "" {
+		return r.setAPIKeyNotReady(ctx, apiKey, "JWTSecretNotReady",
+			fmt.Sprintf("Secret %s-jwt has no %s", project.Name, jwtSecretKey))
+	}
+
+	for name := range apiKey.Spec.Claims {
+		if slices.Contains(secrets.ReservedAPIKeyClaims, name) {
+			return r.setAPIKeyNotReady(ctx, apiKey, "InvalidClaims",
+				fmt.Sprintf("claim %q is set by the operator", name))
+		}
+	}
+
+	expiresAt := apiKeyExpiresAt(apiKey, project)
+	if !expiresAt.After(time.Now()) {
+		return r.setAPIKeyNotReady(ctx, apiKey, "Expired",
+			fmt.Sprintf("API key expired at %s", expiresAt.UTC().Format(time.RFC3339)))
+	}
+
+	secret := &corev1.Secret{}
+	secretExists := true
+	if err := r.Get(ctx, client.ObjectKey{Namespace: apiKey.Namespace, Name: secretName}, secret); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		secretExists = false
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: apiKey.Namespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+	} else if !metav1.IsControlledBy(secret, apiKey) {
+		return r.setAPIKeyNotReady(ctx, apiKey, "SecretConflict",
+			fmt.Sprintf("Secret %s exists and is not owned by this SupabaseAPIKey", secretName))
+	}
+
+	// Tokens are signed again when the spec changes or when they no longer
+	// verify against the project's JWT secret, for example after a rotation.
+	// During a rotation's grace period a token signed with the previous secret
+	// is kept, so that Kong keeps accepting it until the grace period ends.
+	specChecksum := apiKeySpecChecksum(apiKey, expiresAt)
+	token := string(secret.Data[component.APIKeySecretKey])
+	requeueAfter := time.Until(expiresAt)
+	resign := token == "" || secret.Annotations[apiKeySpecChecksumAnnotation] != specChecksum
+	if !resign && secrets.VerifyAPIKey(token, signingSecret, apiKey.Spec.Role) != nil {
+		gracePeriod := jwtGracePeriodRemaining(jwtSecret, time.Now())
+		previousSecret := string(jwtSecret.Data[component.JWTPreviousSecretKey])
+		if gracePeriod > 0 && previousSecret != "" && secrets.VerifyAPIKey(token, previousSecret, apiKey.Spec.Role) == nil {
+			requeueAfter = min(requeueAfter, gracePeriod)
+		} else {
+			resign = true
+		}
+	}
+	if resign {
+		var err error
+		token, _, err = secrets.SignNamedAPIKey(signingSecret, apiKey.Spec.Role, apiKey.Spec.Claims, expiresAt)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[apiKeySpecChecksumAnnotation] = specChecksum
+		secret.Data = map[string][]byte{component.APIKeySecretKey: []byte(token)}
+		if secretExists {
+			if err := r.Update(ctx, secret); err != nil {
+				return ctrl.Result{}, err
+			}
+		} else {
+			if err := controllerutil.SetControllerReference(apiKey, secret, r.Scheme); err != nil {
+				return ctrl.Result{}, err
+			}
+			if err := r.Create(ctx, secret); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		r.Recorder.Eventf(apiKey, corev1.EventTypeNormal, EventReasonAPIKeyIssued, EventMessageAPIKeyIssuedFmt, secretName)
+	}
+
+	keyID, err := secrets.APIKeyID(token)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	apiKey.Status.KeyID = keyID
+	apiKey.Status.ExpiresAt = &metav1.Time{Time: expiresAt}
+	apiKey.Status.ObservedGeneration = apiKey.Generation
+	apiKey.Status.Conditions = status.SetCondition(
+		apiKey.Status.Conditions,
+		status.NewReadyCondition(metav1.ConditionTrue, "Issued", fmt.Sprintf("API key is in Secret %s", secretName)),
+	)
+	if err := r.Status().Update(ctx, apiKey); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Drop the key from Kong once it expires, or sign it with the current
+	// secret once the grace period ends.
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *SupabaseAPIKeyReconciler) setAPIKeyNotReady(ctx context.Context, apiKey *supabasev1alpha1.SupabaseAPIKey, reason, message string) (ctrl.Result, error) {
+	apiKey.Status.ObservedGeneration = apiKey.Generation
+	apiKey.Status.Conditions = status.SetCondition(
+		apiKey.Status.Conditions,
+		status.NewReadyCondition(metav1.ConditionFalse, reason, message),
+	)
+	if err := r.Status().Update(ctx, apiKey); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func apiKeySecretName(apiKey *supabasev1alpha1.SupabaseAPIKey) string {
+	if apiKey.Spec.SecretName != "" {
+		return apiKey.Spec.SecretName
+	}
+	return apiKey.Name
+}
+
+// apiKeyExpiresAt returns spec.expiresAt, or the project's API key TTL after
+// the key was created, so that every key expires.
+func apiKeyExpiresAt(apiKey *supabasev1alpha1.SupabaseAPIKey, project *supabasev1alpha1.SupabaseProject) time.Time {
+	if apiKey.Spec.ExpiresAt != nil {
+		return apiKey.Spec.ExpiresAt.Time
+	}
+	return apiKey.CreationTimestamp.Add(apiKeyTTL(project))
+}
+
+// apiKeySpecChecksum digests the spec fields and expiry that are signed into
+// the token.
+func apiKeySpecChecksum(apiKey *supabasev1alpha1.SupabaseAPIKey, expiresAt time.Time) string {
+	// encoding/json writes map keys in sorted order.
+	data, _ := json.Marshal(struct {
+		Role      string            `json:"role"`
+		Claims    map[string]string `json:"claims"`
+		ExpiresAt int64             `json:"expiresAt"`
+	}{apiKey.Spec.Role, apiKey.Spec.Claims, expiresAt.Unix()})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// apiKeysForProject maps a SupabaseProject to the SupabaseAPIKeys that
+// reference it.
+func (r *SupabaseAPIKeyReconciler) apiKeysForProject(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.apiKeysReferencing(ctx, obj.GetNamespace(), obj.GetName())
+}
+
+// apiKeysForJWTSecret maps a <project>-jwt Secret to the SupabaseAPIKeys of
+// the project, so their tokens are signed again after a rotation.
+func (r *SupabaseAPIKeyReconciler) apiKeysForJWTSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	projectName, ok := strings.CutSuffix(obj.GetName(), "-jwt")
+	if !ok {
+		return nil
+	}
+	return r.apiKeysReferencing(ctx, obj.GetNamespace(), projectName)
+}
+
+func (r *SupabaseAPIKeyReconciler) apiKeysReferencing(ctx context.Context, namespace, projectName string) []reconcile.Request {
+	apiKeys := &supabasev1alpha1.SupabaseAPIKeyList{}
+	if err := r.List(ctx, apiKeys, client.InNamespace(namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list SupabaseAPIKeys for project", "project", projectName)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, apiKey := range apiKeys.Items {
+		if apiKey.Spec.ProjectRef.Name == projectName {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&apiKey)})
+		}
+	}
+	return requests
+}
+
+func (r *SupabaseAPIKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&supabasev1alpha1.SupabaseAPIKey{}).
+		Owns(&corev1.Secret{}).
+		Watches(&supabasev1alpha1.SupabaseProject{}, handler.EnqueueRequestsFromMapFunc(r.apiKeysForProject)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.apiKeysForJWTSecret)).
+		Named("supabaseapikey").
+		Complete(r)
+}
@@ -0,0 +1,225 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/component"
+	"github.com/strrl/supabase-operator/internal/secrets"
+)
+
+var _ = Describe("SupabaseAPIKey Controller", func() {
+	Context("When reconciling a resource", func() {
+		const (
+			resourceName = "test-api-key"
+			projectName  = "test-api-key-project"
+		)
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		var jwtSecret string
+
+		BeforeEach(func() {
+			By("creating the project the key belongs to")
+			project := &supabasev1alpha1.SupabaseProject{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      projectName,
+					Namespace: "default",
+				},
+				Spec: supabasev1alpha1.SupabaseProjectSpec{
+					ProjectID: projectName,
+					Database: supabasev1alpha1.DatabaseConfig{
+						SecretRef: corev1.SecretReference{Name: "test-db-secret"},
+					},
+					Storage: supabasev1alpha1.StorageConfig{
+						SecretRef: corev1.SecretReference{Name: "test-storage-secret"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, project)).To(Succeed())
+
+			By("creating the JWT secret of the project")
+			var err error
+			jwtSecret, err = secrets.GenerateJWTSecret()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      projectName + "-jwt",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{jwtSecretKey: []byte(jwtSecret)},
+			})).To(Succeed())
+
+			By("creating the custom resource for the Kind SupabaseAPIKey")
+			Expect(k8sClient.Create(ctx, &supabasev1alpha1.SupabaseAPIKey{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: supabasev1alpha1.SupabaseAPIKeySpec{
+					ProjectRef: corev1.LocalObjectReference{Name: projectName},
+					Role:       "reporting",
+					Claims:     map[string]string{"team": "billing"},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the SupabaseAPIKey, its project and their Secrets")
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &supabasev1alpha1.SupabaseAPIKey{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			}))).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &supabasev1alpha1.SupabaseProject{
+				ObjectMeta: metav1.ObjectMeta{Name: projectName, Namespace: "default"},
+			})).To(Succeed())
+			for _, name := range []string{resourceName, projectName + "-jwt"} {
+				secret := &corev1.Secret{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, secret); err == nil {
+					Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+				}
+			}
+		})
+
+		It("should sign the key into a Secret", func() {
+			By("Reconciling the created resource")
+			controllerReconciler := &SupabaseAPIKeyReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, secret)).To(Succeed())
+			Expect(secrets.VerifyAPIKey(string(secret.Data[component.APIKeySecretKey]), jwtSecret, "reporting")).To(Succeed())
+
+			apiKey := &supabasev1alpha1.SupabaseAPIKey{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, apiKey)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(apiKey.Status.Conditions, "Ready")).To(BeTrue())
+			Expect(apiKey.Status.SecretName).To(Equal(resourceName))
+			Expect(apiKey.Status.KeyID).NotTo(BeEmpty())
+			Expect(apiKey.Status.ExpiresAt).NotTo(BeNil())
+			Expect(apiKey.Status.ExpiresAt.After(apiKey.CreationTimestamp.Add(secrets.DefaultAPIKeyTTL - time.Minute))).To(BeTrue())
+		})
+
+		It("should keep the key valid during a JWT rotation's grace period", func() {
+			controllerReconciler := &SupabaseAPIKeyReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, secret)).To(Succeed())
+			token := string(secret.Data[component.APIKeySecretKey])
+			apiKey := &supabasev1alpha1.SupabaseAPIKey{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, apiKey)).To(Succeed())
+			keyID := apiKey.Status.KeyID
+
+			By("rotating the JWT secret with a grace period")
+			rotatedSecret, err := secrets.GenerateJWTSecret()
+			Expect(err).NotTo(HaveOccurred())
+			projectJWT := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: projectName + "-jwt", Namespace: "default"}, projectJWT)).To(Succeed())
+			projectJWT.Annotations = map[string]string{
+				jwtPreviousExpiresAtAnnotation: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+			}
+			projectJWT.Data = map[string][]byte{
+				jwtSecretKey:                   []byte(rotatedSecret),
+				component.JWTPreviousSecretKey: []byte(jwtSecret),
+			}
+			Expect(k8sClient.Update(ctx, projectJWT)).To(Succeed())
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, secret)).To(Succeed())
+			Expect(string(secret.Data[component.APIKeySecretKey])).To(Equal(token))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, apiKey)).To(Succeed())
+			Expect(apiKey.Status.KeyID).To(Equal(keyID))
+
+			// Kong's pre-function keeps accepting the old token.
+			project := &supabasev1alpha1.SupabaseProject{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: projectName, Namespace: "default"}, project)).To(Succeed())
+			projectReconciler := &SupabaseProjectReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			apiKeys, err := projectReconciler.readyAPIKeys(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiKeys).To(HaveLen(1))
+			Expect(apiKeys[0].KeyID).To(Equal(keyID))
+
+			By("ending the grace period")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: projectName + "-jwt", Namespace: "default"}, projectJWT)).To(Succeed())
+			delete(projectJWT.Annotations, jwtPreviousExpiresAtAnnotation)
+			delete(projectJWT.Data, component.JWTPreviousSecretKey)
+			Expect(k8sClient.Update(ctx, projectJWT)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, secret)).To(Succeed())
+			Expect(secrets.VerifyAPIKey(string(secret.Data[component.APIKeySecretKey]), rotatedSecret, "reporting")).To(Succeed())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, apiKey)).To(Succeed())
+			Expect(apiKey.Status.KeyID).NotTo(Equal(keyID))
+		})
+
+		It("should drop a deleted key from the keys Kong accepts", func() {
+			controllerReconciler := &SupabaseAPIKeyReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			project := &supabasev1alpha1.SupabaseProject{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: projectName, Namespace: "default"}, project)).To(Succeed())
+			projectReconciler := &SupabaseProjectReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			apiKeys, err := projectReconciler.readyAPIKeys(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiKeys).To(HaveLen(1))
+			Expect(apiKeys[0].KeyID).NotTo(BeEmpty())
+
+			By("deleting the SupabaseAPIKey")
+			Expect(k8sClient.Delete(ctx, &supabasev1alpha1.SupabaseAPIKey{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+			})).To(Succeed())
+			apiKeys, err = projectReconciler.readyAPIKeys(ctx, project)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apiKeys).To(BeEmpty())
+
+			// Kong's pre-function rejects every jti that is not in the list.
+			config := component.BuildKongConfigMap(project, apiKeys).Data["kong.yml"]
+			Expect(config).To(ContainSubstring("local active = { }"))
+		})
+	})
+})
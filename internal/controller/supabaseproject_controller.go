@@ -2,24 +2,38 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
 	"github.com/strrl/supabase-operator/internal/component"
 	"github.com/strrl/supabase-operator/internal/controller/reconciler"
+	"github.com/strrl/supabase-operator/internal/database"
 	"github.com/strrl/supabase-operator/internal/secrets"
 	"github.com/strrl/supabase-operator/internal/status"
 )
@@ -30,23 +44,40 @@ const (
 	anonKeyKey      = "anon-key"
 	serviceRoleKey  = "service-role-key"
 	pgMetaCryptoKey = "pg-meta-crypto-key"
+	samlPrivateKey  = "saml-private-key"
+
+	realtimeDBEncKey      = "realtime-db-enc-key"
+	realtimeSecretKeyBase = "realtime-secret-key-base"
+
+	// realtimeLegacyDBEncKey is the DB_ENC_KEY every install used before the
+	// key was generated per project.
+	realtimeLegacyDBEncKey = "supabaserealtime"
 )
 
 type SupabaseProjectReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// authHookChecks maps a project's UID to the digest of the hooks and
+	// database Secret its pg-functions hooks were last found valid for, so
+	// the database is only queried again when either changes.
+	authHookChecks sync.Map
 }
 
 // +kubebuilder:rbac:groups=supabase.strrl.dev,resources=supabaseprojects,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=supabase.strrl.dev,resources=supabaseprojects/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=supabase.strrl.dev,resources=supabaseprojects/finalizers,verbs=update
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=supabase.strrl.dev,resources=supabaseapikeys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create
 // +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes;backendtlspolicies,verbs=get;list;watch;create;update;patch;delete
 
 func (r *SupabaseProjectReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -124,6 +155,60 @@ func (r *SupabaseProjectReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		}
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
+
+	jwtSecret, err := r.reconcileJWTRotation(ctx, project)
+	if err != nil {
+		logger.Error(err, "Failed to rotate JWT secret")
+		project.Status.Phase = status.PhaseFailed
+		project.Status.Message = fmt.Sprintf("Secret generation failed: %v", err)
+		r.Recorder.Eventf(project, corev1.EventTypeWarning, EventReasonSecretsFailed, EventMessageSecretsFailedFmt, err)
+		if updateErr := r.Status().Update(ctx, project); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if err := r.reconcileAPIKeyExpiry(ctx, project, jwtSecret); err != nil {
+		logger.Error(err, "Failed to renew API keys")
+		project.Status.Phase = status.PhaseFailed
+		project.Status.Message = fmt.Sprintf("Secret generation failed: %v", err)
+		r.Recorder.Eventf(project, corev1.EventTypeWarning, EventReasonSecretsFailed, EventMessageSecretsFailedFmt, err)
+		if updateErr := r.Status().Update(ctx, project); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+
+	if err := r.ensureSAMLSecret(ctx, project); err != nil {
+		logger.Error(err, "Failed to ensure SAML secret")
+		project.Status.Phase = status.PhaseFailed
+		project.Status.Message = fmt.Sprintf("Secret generation failed: %v", err)
+		r.Recorder.Eventf(project, corev1.EventTypeWarning, EventReasonSecretsFailed, EventMessageSecretsFailedFmt, err)
+		if updateErr := r.Status().Update(ctx, project); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	if err := r.ensurePoolerSecret(ctx, project); err != nil {
+		logger.Error(err, "Failed to ensure pooler secret")
+		project.Status.Phase = status.PhaseFailed
+		project.Status.Message = fmt.Sprintf("Secret generation failed: %v", err)
+		r.Recorder.Eventf(project, corev1.EventTypeWarning, EventReasonSecretsFailed, EventMessageSecretsFailedFmt, err)
+		if updateErr := r.Status().Update(ctx, project); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
+	if err := r.ensureAnalyticsSecret(ctx, project); err != nil {
+		logger.Error(err, "Failed to ensure analytics secret")
+		project.Status.Phase = status.PhaseFailed
+		project.Status.Message = fmt.Sprintf("Secret generation failed: %v", err)
+		r.Recorder.Eventf(project, corev1.EventTypeWarning, EventReasonSecretsFailed, EventMessageSecretsFailedFmt, err)
+		if updateErr := r.Status().Update(ctx, project); updateErr != nil {
+			return ctrl.Result{}, updateErr
+		}
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+	}
 	r.Recorder.Event(project, corev1.EventTypeNormal, EventReasonSecretsCreated, EventMessageSecretsCreated)
 
 	// Initialize database with required extensions and roles via Kubernetes Job
@@ -155,12 +240,21 @@ func (r *SupabaseProjectReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	project.Status.Phase = status.PhaseDeployingComponents
 	r.Recorder.Event(project, corev1.EventTypeNormal, EventReasonPhaseChanged, EventMessageDeployingComponents)
 
-	componentsStatus, err := r.reconcileAllComponents(ctx, project)
+	jwtChecksum := component.JWTChecksum(jwtSecret.Data)
+	componentsStatus, err := r.reconcileAllComponents(ctx, project, jwtChecksum)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	pendingConsumers, err := r.pendingJWTConsumers(ctx, project, jwtChecksum)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	project.Status.Conditions = status.SetCondition(project.Status.Conditions, jwtRotationCondition(project, jwtSecret, pendingConsumers))
 
 	project.Status.Components = componentsStatus
+	project.Status.Endpoints = status.NewEndpointsStatus(projectBaseURL(project))
+	project.Status.APIKeys = status.NewAPIKeysStatus(jwtSecret)
 	project.Status.Phase = status.PhaseRunning
 	project.Status.Message = status.GetPhaseMessage(status.PhaseRunning)
 	project.Status.Conditions = status.SetCondition(
@@ -184,10 +278,20 @@ func (r *SupabaseProjectReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		logger.Info("Successfully reconciled SupabaseProject")
 	}
 
+	// Come back when the previous JWT secret has to be dropped or an API key
+	// has to be re-minted, whichever comes first.
+	requeueAfter := jwtGracePeriodRemaining(jwtSecret, now.Time)
+	if renewal := apiKeyRenewalRemaining(project, jwtSecret, now.Time); renewal > 0 && (requeueAfter <= 0 || renewal < requeueAfter) {
+		requeueAfter = renewal
+	}
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context, project *supabasev1alpha1.SupabaseProject) (supabasev1alpha1.ComponentsStatus, error) {
+func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context, project *supabasev1alpha1.SupabaseProject, jwtChecksum string) (supabasev1alpha1.ComponentsStatus, error) {
 	logger := log.FromContext(ctx)
 	componentsStatus := supabasev1alpha1.ComponentsStatus{}
 
@@ -196,7 +300,12 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 		Scheme: r.Scheme,
 	}
 
-	kongConfigMap := component.BuildKongConfigMap(project)
+	apiKeys, err := r.readyAPIKeys(ctx, project)
+	if err != nil {
+		return componentsStatus, err
+	}
+
+	kongConfigMap := component.BuildKongConfigMap(project, apiKeys)
 	if err := controllerutil.SetControllerReference(project, kongConfigMap, r.Scheme); err != nil {
 		return componentsStatus, err
 	}
@@ -217,7 +326,7 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 		}
 	}
 
-	if err := componentReconciler.ReconcileComponent(ctx, project, &component.KongBuilder{}); err != nil {
+	if err := componentReconciler.ReconcileComponent(ctx, project, &component.KongBuilder{JWTChecksum: jwtChecksum, APIKeys: apiKeys}); err != nil {
 		logger.Error(err, "Failed to reconcile Kong")
 		return componentsStatus, err
 	}
@@ -233,7 +342,27 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 			status.NewComponentStatus(status.PhaseRunning, project.Spec.Kong.Image, replicas, kongDeploy.Status.ReadyReplicas))
 	}
 
-	if err := componentReconciler.ReconcileComponent(ctx, project, &component.AuthBuilder{}); err != nil {
+	if err := r.reconcileIngress(ctx, project); err != nil {
+		logger.Error(err, "Failed to reconcile Ingress")
+		return componentsStatus, err
+	}
+
+	if err := r.reconcileGateway(ctx, project); err != nil {
+		logger.Error(err, "Failed to reconcile Gateway routes")
+		return componentsStatus, err
+	}
+
+	if err := r.reconcilePooler(ctx, project, componentReconciler, &componentsStatus, jwtChecksum); err != nil {
+		logger.Error(err, "Failed to reconcile Pooler")
+		return componentsStatus, err
+	}
+
+	samlKeyChecksum, err := r.samlKeyChecksum(ctx, project)
+	if err != nil {
+		logger.Error(err, "Failed to read SAML private key")
+		return componentsStatus, err
+	}
+	if err := componentReconciler.ReconcileComponent(ctx, project, &component.AuthBuilder{JWTChecksum: jwtChecksum, SAMLKeyChecksum: samlKeyChecksum}); err != nil {
 		logger.Error(err, "Failed to reconcile Auth")
 		return componentsStatus, err
 	}
@@ -249,7 +378,7 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 			status.NewComponentStatus(status.PhaseRunning, project.Spec.Auth.Image, replicas, authDeploy.Status.ReadyReplicas))
 	}
 
-	if err := componentReconciler.ReconcileComponent(ctx, project, &component.PostgRESTBuilder{}); err != nil {
+	if err := componentReconciler.ReconcileComponent(ctx, project, &component.PostgRESTBuilder{JWTChecksum: jwtChecksum}); err != nil {
 		logger.Error(err, "Failed to reconcile PostgREST")
 		return componentsStatus, err
 	}
@@ -265,7 +394,7 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 			status.NewComponentStatus(status.PhaseRunning, project.Spec.PostgREST.Image, replicas, postgrestDeploy.Status.ReadyReplicas))
 	}
 
-	if err := componentReconciler.ReconcileComponent(ctx, project, &component.RealtimeBuilder{}); err != nil {
+	if err := componentReconciler.ReconcileComponent(ctx, project, &component.RealtimeBuilder{JWTChecksum: jwtChecksum}); err != nil {
 		logger.Error(err, "Failed to reconcile Realtime")
 		return componentsStatus, err
 	}
@@ -281,7 +410,33 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 			status.NewComponentStatus(status.PhaseRunning, project.Spec.Realtime.Image, replicas, realtimeDeploy.Status.ReadyReplicas))
 	}
 
-	if err := componentReconciler.ReconcileComponent(ctx, project, &component.StorageBuilder{}); err != nil {
+	if err := r.ensureStoragePVC(ctx, project); err != nil {
+		logger.Error(err, "Failed to ensure Storage PVC")
+		return componentsStatus, err
+	}
+
+	if component.ImgproxyEnabled(project) {
+		if err := componentReconciler.ReconcileComponent(ctx, project, &component.ImgproxyBuilder{}); err != nil {
+			logger.Error(err, "Failed to reconcile Imgproxy")
+			return componentsStatus, err
+		}
+		imgproxyDeploy := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: project.Name + "-imgproxy"}, imgproxyDeploy); err != nil {
+			logger.Error(err, "Failed to get Imgproxy deployment status")
+		} else {
+			replicas := int32(0)
+			if imgproxyDeploy.Spec.Replicas != nil {
+				replicas = *imgproxyDeploy.Spec.Replicas
+			}
+			componentsStatus = status.SetComponentStatus(componentsStatus, "Imgproxy",
+				status.NewComponentStatus(status.PhaseRunning, project.Spec.Imgproxy.Image, replicas, imgproxyDeploy.Status.ReadyReplicas))
+		}
+	} else if err := componentReconciler.DeleteComponent(ctx, project, &component.ImgproxyBuilder{}); err != nil {
+		logger.Error(err, "Failed to remove Imgproxy")
+		return componentsStatus, err
+	}
+
+	if err := componentReconciler.ReconcileComponent(ctx, project, &component.StorageBuilder{JWTChecksum: jwtChecksum}); err != nil {
 		logger.Error(err, "Failed to reconcile Storage")
 		return componentsStatus, err
 	}
@@ -297,6 +452,16 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 			status.NewComponentStatus(status.PhaseRunning, project.Spec.StorageAPI.Image, replicas, storageDeploy.Status.ReadyReplicas))
 	}
 
+	if err := r.reconcileFunctions(ctx, project, componentReconciler, &componentsStatus, jwtChecksum); err != nil {
+		logger.Error(err, "Failed to reconcile Functions")
+		return componentsStatus, err
+	}
+
+	if err := r.reconcileAnalytics(ctx, project, componentReconciler, &componentsStatus); err != nil {
+		logger.Error(err, "Failed to reconcile Analytics")
+		return componentsStatus, err
+	}
+
 	if err := componentReconciler.ReconcileComponent(ctx, project, &component.MetaBuilder{}); err != nil {
 		logger.Error(err, "Failed to reconcile Meta")
 		return componentsStatus, err
@@ -313,7 +478,7 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 			status.NewComponentStatus(status.PhaseRunning, project.Spec.Meta.Image, replicas, metaDeploy.Status.ReadyReplicas))
 	}
 
-	if err := componentReconciler.ReconcileComponent(ctx, project, &component.StudioBuilder{}); err != nil {
+	if err := componentReconciler.ReconcileComponent(ctx, project, &component.StudioBuilder{JWTChecksum: jwtChecksum}); err != nil {
 		logger.Error(err, "Failed to reconcile Studio")
 		return componentsStatus, err
 	}
@@ -332,6 +497,319 @@ func (r *SupabaseProjectReconciler) reconcileAllComponents(ctx context.Context,
 	return componentsStatus, nil
 }
 
+// reconcileIngress keeps the Kong Ingress in sync with spec.ingress and removes
+// it once the Ingress is disabled.
+func (r *SupabaseProjectReconciler) reconcileIngress(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	ingress := component.BuildIngress(project)
+
+	existingIngress := &networkingv1.Ingress{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: ingress.Namespace, Name: ingress.Name}, existingIngress)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	if !component.IngressEnabled(project) {
+		if found && metav1.IsControlledBy(existingIngress, project) {
+			if err := r.Delete(ctx, existingIngress); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete ingress: %w", err)
+			}
+		}
+		return nil
+	}
+
+	if err := controllerutil.SetControllerReference(project, ingress, r.Scheme); err != nil {
+		return err
+	}
+
+	if !found {
+		if err := r.Create(ctx, ingress); err != nil {
+			return fmt.Errorf("failed to create ingress: %w", err)
+		}
+		return nil
+	}
+
+	if !metav1.IsControlledBy(existingIngress, project) {
+		return fmt.Errorf("ingress %s exists and is not owned by this SupabaseProject", ingress.Name)
+	}
+
+	// Labels and annotations set by other controllers, such as the ingress
+	// controller or cert-manager, are kept; only the operator's are merged in.
+	specChanged := !equality.Semantic.DeepEqual(existingIngress.Spec, ingress.Spec)
+	labelsChanged := mergeStringMap(&existingIngress.Labels, ingress.Labels)
+	annotationsChanged := mergeStringMap(&existingIngress.Annotations, ingress.Annotations)
+	if !specChanged && !labelsChanged && !annotationsChanged {
+		return nil
+	}
+	existingIngress.Spec = ingress.Spec
+	if err := r.Update(ctx, existingIngress); err != nil {
+		return fmt.Errorf("failed to update ingress: %w", err)
+	}
+	return nil
+}
+
+// mergeStringMap sets the entries of src in *dst and reports whether that
+// changed *dst.
+func mergeStringMap(dst *map[string]string, src map[string]string) bool {
+	changed := false
+	for key, value := range src {
+		if current, ok := (*dst)[key]; ok && current == value {
+			continue
+		}
+		if *dst == nil {
+			*dst = map[string]string{}
+		}
+		(*dst)[key] = value
+		changed = true
+	}
+	return changed
+}
+
+// reconcileGateway keeps the Kong HTTPRoute and BackendTLSPolicy in sync with
+// spec.gateway. Clusters without the Gateway API CRDs are only an error when
+// the gateway is actually enabled.
+func (r *SupabaseProjectReconciler) reconcileGateway(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	route, err := component.BuildHTTPRoute(project)
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileOptionalObject(ctx, project, route, &gatewayv1.HTTPRoute{}, component.GatewayEnabled(project),
+		func(existing client.Object) { existing.(*gatewayv1.HTTPRoute).Spec = route.Spec }); err != nil {
+		return fmt.Errorf("failed to reconcile httproute: %w", err)
+	}
+
+	policy, err := component.BuildBackendTLSPolicy(project)
+	if err != nil {
+		return err
+	}
+	if err := r.reconcileOptionalObject(ctx, project, policy, &gatewayv1.BackendTLSPolicy{}, component.GatewayBackendTLSEnabled(project),
+		func(existing client.Object) { existing.(*gatewayv1.BackendTLSPolicy).Spec = policy.Spec }); err != nil {
+		return fmt.Errorf("failed to reconcile backendtlspolicy: %w", err)
+	}
+
+	return nil
+}
+
+// reconcileOptionalObject creates or updates desired when enabled, and deletes
+// the existing object when disabled and owned by the project. A kind the
+// cluster does not serve is only an error when the object is enabled.
+func (r *SupabaseProjectReconciler) reconcileOptionalObject(ctx context.Context, project *supabasev1alpha1.SupabaseProject, desired, existing client.Object, enabled bool, copySpec func(client.Object)) error {
+	err := r.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if err != nil && !apierrors.IsNotFound(err) {
+		if !enabled && (meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)) {
+			return nil
+		}
+		return err
+	}
+	found := err == nil
+
+	if !enabled {
+		if found && metav1.IsControlledBy(existing, project) {
+			if err := r.Delete(ctx, existing); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := controllerutil.SetControllerReference(project, desired, r.Scheme); err != nil {
+		return err
+	}
+
+	if !found {
+		return r.Create(ctx, desired)
+	}
+
+	existing.SetLabels(desired.GetLabels())
+	copySpec(existing)
+	return r.Update(ctx, existing)
+}
+
+// reconcileFunctions deploys the edge runtime with its main service ConfigMap,
+// or removes both once functions are disabled. The function ConfigMaps are
+// digested so that a code change rolls the pods.
+func (r *SupabaseProjectReconciler) reconcileFunctions(ctx context.Context, project *supabasev1alpha1.SupabaseProject, componentReconciler *reconciler.ComponentReconciler, componentsStatus *supabasev1alpha1.ComponentsStatus, jwtChecksum string) error {
+	enabled := component.FunctionsEnabled(project)
+
+	mainConfigMap := component.BuildFunctionsMainConfigMap(project)
+	if err := r.reconcileOptionalObject(ctx, project, mainConfigMap, &corev1.ConfigMap{}, enabled,
+		func(existing client.Object) { existing.(*corev1.ConfigMap).Data = mainConfigMap.Data }); err != nil {
+		return fmt.Errorf("failed to reconcile functions main configmap: %w", err)
+	}
+
+	if !enabled {
+		return componentReconciler.DeleteComponent(ctx, project, &component.EdgeRuntimeBuilder{})
+	}
+
+	var configMaps []corev1.ConfigMap
+	for _, name := range component.FunctionsConfigMapNames(project) {
+		configMap := corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: name}, &configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("functions configmap '%s' not found", name)
+			}
+			return err
+		}
+		configMaps = append(configMaps, configMap)
+	}
+
+	builder := &component.EdgeRuntimeBuilder{JWTChecksum: jwtChecksum}
+	if len(configMaps) > 0 {
+		builder.CodeChecksum = component.FunctionsChecksum(configMaps)
+	}
+	if err := componentReconciler.ReconcileComponent(ctx, project, builder); err != nil {
+		return err
+	}
+
+	functionsDeploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: project.Name + "-functions"}, functionsDeploy); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get Functions deployment status")
+		return nil
+	}
+	replicas := int32(0)
+	if functionsDeploy.Spec.Replicas != nil {
+		replicas = *functionsDeploy.Spec.Replicas
+	}
+	*componentsStatus = status.SetComponentStatus(*componentsStatus, "Functions",
+		status.NewComponentStatus(status.PhaseRunning, project.Spec.Functions.Image, replicas, functionsDeploy.Status.ReadyReplicas))
+	return nil
+}
+
+// reconcilePooler deploys Supavisor with its tenant bootstrap ConfigMap, or
+// removes both once the pooler is disabled. It runs before the components that
+// may be routed through it.
+func (r *SupabaseProjectReconciler) reconcilePooler(ctx context.Context, project *supabasev1alpha1.SupabaseProject, componentReconciler *reconciler.ComponentReconciler, componentsStatus *supabasev1alpha1.ComponentsStatus, jwtChecksum string) error {
+	enabled := component.PoolerEnabled(project)
+
+	configMap := component.BuildPoolerConfigMap(project)
+	if err := r.reconcileOptionalObject(ctx, project, configMap, &corev1.ConfigMap{}, enabled,
+		func(existing client.Object) { existing.(*corev1.ConfigMap).Data = configMap.Data }); err != nil {
+		return fmt.Errorf("failed to reconcile pooler configmap: %w", err)
+	}
+
+	if !enabled {
+		return componentReconciler.DeleteComponent(ctx, project, &component.SupavisorBuilder{})
+	}
+
+	if err := componentReconciler.ReconcileComponent(ctx, project, &component.SupavisorBuilder{JWTChecksum: jwtChecksum}); err != nil {
+		return err
+	}
+
+	poolerDeploy := &appsv1.Deployment{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: project.Name + "-pooler"}, poolerDeploy); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to get Pooler deployment status")
+		return nil
+	}
+	replicas := int32(0)
+	if poolerDeploy.Spec.Replicas != nil {
+		replicas = *poolerDeploy.Spec.Replicas
+	}
+	*componentsStatus = status.SetComponentStatus(*componentsStatus, "Pooler",
+		status.NewComponentStatus(status.PhaseRunning, project.Spec.Pooler.Image, replicas, poolerDeploy.Status.ReadyReplicas))
+	return nil
+}
+
+// ensureStoragePVC creates the claim of the file storage backend. The claim is
+// never updated or deleted by the operator so that stored objects survive
+// spec changes; it is only garbage collected with the project.
+func (r *SupabaseProjectReconciler) ensureStoragePVC(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if !component.StorageFileBackend(project) {
+		return nil
+	}
+
+	pvc := component.BuildStoragePVC(project)
+	err := r.Get(ctx, client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{})
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if err := controllerutil.SetControllerReference(project, pvc, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, pvc)
+}
+
+// reconcileAnalytics deploys Logflare and the Vector DaemonSet that feeds it,
+// or removes them once disabled. Vector only runs while analytics does.
+func (r *SupabaseProjectReconciler) reconcileAnalytics(ctx context.Context, project *supabasev1alpha1.SupabaseProject, componentReconciler *reconciler.ComponentReconciler, componentsStatus *supabasev1alpha1.ComponentsStatus) error {
+	logger := log.FromContext(ctx)
+
+	if component.AnalyticsEnabled(project) {
+		if err := componentReconciler.ReconcileComponent(ctx, project, &component.AnalyticsBuilder{}); err != nil {
+			return err
+		}
+		analyticsDeploy := &appsv1.Deployment{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: project.Name + "-analytics"}, analyticsDeploy); err != nil {
+			logger.Error(err, "Failed to get Analytics deployment status")
+		} else {
+			replicas := int32(0)
+			if analyticsDeploy.Spec.Replicas != nil {
+				replicas = *analyticsDeploy.Spec.Replicas
+			}
+			*componentsStatus = status.SetComponentStatus(*componentsStatus, "Analytics",
+				status.NewComponentStatus(status.PhaseRunning, project.Spec.Analytics.Image, replicas, analyticsDeploy.Status.ReadyReplicas))
+		}
+	} else if err := componentReconciler.DeleteComponent(ctx, project, &component.AnalyticsBuilder{}); err != nil {
+		return err
+	}
+
+	vectorEnabled := component.VectorEnabled(project)
+
+	vectorConfigMap := component.BuildVectorConfigMap(project)
+	if err := r.reconcileOptionalObject(ctx, project, vectorConfigMap, &corev1.ConfigMap{}, vectorEnabled,
+		func(existing client.Object) { existing.(*corev1.ConfigMap).Data = vectorConfigMap.Data }); err != nil {
+		return fmt.Errorf("failed to reconcile vector configmap: %w", err)
+	}
+
+	vectorDaemonSet := component.BuildVectorDaemonSet(project)
+	existingDaemonSet := &appsv1.DaemonSet{}
+	if err := r.reconcileOptionalObject(ctx, project, vectorDaemonSet, existingDaemonSet, vectorEnabled,
+		func(existing client.Object) { existing.(*appsv1.DaemonSet).Spec = vectorDaemonSet.Spec }); err != nil {
+		return fmt.Errorf("failed to reconcile vector daemonset: %w", err)
+	}
+
+	if vectorEnabled {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(vectorDaemonSet), existingDaemonSet); err != nil {
+			logger.Error(err, "Failed to get Vector daemonset status")
+			return nil
+		}
+		*componentsStatus = status.SetComponentStatus(*componentsStatus, "Vector",
+			status.NewComponentStatus(status.PhaseRunning, project.Spec.Vector.Image,
+				existingDaemonSet.Status.DesiredNumberScheduled, existingDaemonSet.Status.NumberReady))
+	}
+	return nil
+}
+
+// projectsForFunctionsConfigMap enqueues the projects in the ConfigMap's
+// namespace that load function code from it.
+func (r *SupabaseProjectReconciler) projectsForFunctionsConfigMap(ctx context.Context, obj client.Object) []reconcile.Request {
+	projects := &supabasev1alpha1.SupabaseProjectList{}
+	if err := r.List(ctx, projects, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list SupabaseProjects for functions configmap", "configmap", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, project := range projects.Items {
+		if slices.Contains(component.FunctionsConfigMapNames(&project), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&project)})
+		}
+	}
+	return requests
+}
+
+// projectBaseURL returns the URL clients use to reach Kong: the Ingress host
+// when one is configured, otherwise the in-cluster Kong Service address.
+func projectBaseURL(project *supabasev1alpha1.SupabaseProject) string {
+	if ingressURL := component.IngressURL(project); ingressURL != "" {
+		return ingressURL
+	}
+	return fmt.Sprintf("http://%s-kong.%s.svc:8000", project.Name, project.Namespace)
+}
+
 func (r *SupabaseProjectReconciler) validateDependencies(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
 	dbSecret := &corev1.Secret{}
 	if err := r.Get(ctx, client.ObjectKey{
@@ -345,16 +823,19 @@ func (r *SupabaseProjectReconciler) validateDependencies(ctx context.Context, pr
 		return fmt.Errorf("database secret validation failed: %w", err)
 	}
 
-	storageSecret := &corev1.Secret{}
-	if err := r.Get(ctx, client.ObjectKey{
-		Namespace: project.Namespace,
-		Name:      project.Spec.Storage.SecretRef.Name,
-	}, storageSecret); err != nil {
-		return fmt.Errorf("failed to get storage secret: %w", err)
-	}
+	// The file backend keeps objects on a PVC, there is no S3 to check.
+	if !component.StorageFileBackend(project) {
+		storageSecret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{
+			Namespace: project.Namespace,
+			Name:      project.Spec.Storage.SecretRef.Name,
+		}, storageSecret); err != nil {
+			return fmt.Errorf("failed to get storage secret: %w", err)
+		}
 
-	if err := secrets.ValidateStorageSecret(storageSecret); err != nil {
-		return fmt.Errorf("storage secret validation failed: %w", err)
+		if err := secrets.ValidateStorageSecret(storageSecret); err != nil {
+			return fmt.Errorf("storage secret validation failed: %w", err)
+		}
 	}
 
 	if project.Spec.Studio != nil && project.Spec.Studio.DashboardBasicAuthSecretRef != nil {
@@ -374,6 +855,169 @@ func (r *SupabaseProjectReconciler) validateDependencies(ctx context.Context, pr
 		}
 	}
 
+	if err := r.validateAuthHooks(ctx, project, dbSecret); err != nil {
+		return err
+	}
+
+	if err := r.validateSMTPSecret(ctx, project); err != nil {
+		return err
+	}
+
+	if err := r.validateSMSSecret(ctx, project); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateSMSSecret checks the SMS provider credentials and records the outcome
+// in the SMSConfigured condition.
+func (r *SupabaseProjectReconciler) validateSMSSecret(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Auth == nil || project.Spec.Auth.SMS == nil {
+		project.Status.Conditions = status.SetCondition(
+			project.Status.Conditions,
+			status.NewComponentCondition(status.ConditionTypeSMSConfigured, metav1.ConditionFalse, "NotConfigured", "No SMS provider configured, phone sign-in is disabled"),
+		)
+		return nil
+	}
+
+	sms := project.Spec.Auth.SMS
+	namespace := sms.SecretRef.Namespace
+	if namespace == "" {
+		namespace = project.Namespace
+	}
+
+	smsSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: sms.SecretRef.Name}, smsSecret); err != nil {
+		project.Status.Conditions = status.SetCondition(
+			project.Status.Conditions,
+			status.NewComponentCondition(status.ConditionTypeSMSConfigured, metav1.ConditionFalse, "SecretNotFound", err.Error()),
+		)
+		return fmt.Errorf("failed to get sms secret: %w", err)
+	}
+
+	if err := secrets.ValidateSMSSecret(sms.Provider, smsSecret); err != nil {
+		project.Status.Conditions = status.SetCondition(
+			project.Status.Conditions,
+			status.NewComponentCondition(status.ConditionTypeSMSConfigured, metav1.ConditionFalse, "SecretInvalid", err.Error()),
+		)
+		return fmt.Errorf("sms secret validation failed: %w", err)
+	}
+
+	project.Status.Conditions = status.SetCondition(
+		project.Status.Conditions,
+		status.NewComponentCondition(status.ConditionTypeSMSConfigured, metav1.ConditionTrue, "SecretValid", fmt.Sprintf("SMS provider %s is configured", sms.Provider)),
+	)
+	return nil
+}
+
+// validateAuthHooks checks that every pg-functions hook points at an existing
+// function and records the outcome in the AuthHooksReady condition.
+func (r *SupabaseProjectReconciler) validateAuthHooks(ctx context.Context, project *supabasev1alpha1.SupabaseProject, dbSecret *corev1.Secret) error {
+	var hooks []supabasev1alpha1.AuthHook
+	if project.Spec.Auth != nil {
+		hooks = project.Spec.Auth.Hooks
+	}
+
+	dbConfig := database.InitConfig{
+		Host:     string(dbSecret.Data["host"]),
+		Port:     string(dbSecret.Data["port"]),
+		Database: string(dbSecret.Data["database"]),
+		Username: string(dbSecret.Data["username"]),
+		Password: string(dbSecret.Data["password"]),
+		SSLMode:  component.DatabaseSSLMode(project),
+	}
+
+	checksum := authHooksChecksum(hooks, dbConfig)
+	if cached, ok := r.authHookChecks.Load(project.UID); ok && cached == checksum &&
+		meta.IsStatusConditionTrue(project.Status.Conditions, status.ConditionTypeAuthHooksReady) {
+		return nil
+	}
+
+	for _, hook := range hooks {
+		if !strings.HasPrefix(hook.URI, database.PGFunctionsScheme+"://") {
+			continue
+		}
+
+		schema, function, err := database.ParsePGFunctionURI(hook.URI)
+		if err == nil {
+			var exists bool
+			exists, err = database.FunctionExists(ctx, dbConfig, schema, function)
+			if err == nil && !exists {
+				err = fmt.Errorf("function %s.%s does not exist", schema, function)
+			}
+		}
+		if err != nil {
+			project.Status.Conditions = status.SetCondition(
+				project.Status.Conditions,
+				status.NewComponentCondition(status.ConditionTypeAuthHooksReady, metav1.ConditionFalse, "HookFunctionNotFound",
+					fmt.Sprintf("%s hook: %v", hook.Type, err)),
+			)
+			return fmt.Errorf("%s hook validation failed: %w", hook.Type, err)
+		}
+	}
+
+	message := "No auth hooks configured"
+	if len(hooks) > 0 {
+		message = fmt.Sprintf("%d auth hook(s) configured", len(hooks))
+	}
+	project.Status.Conditions = status.SetCondition(
+		project.Status.Conditions,
+		status.NewComponentCondition(status.ConditionTypeAuthHooksReady, metav1.ConditionTrue, "HooksValid", message),
+	)
+	r.authHookChecks.Store(project.UID, checksum)
+	return nil
+}
+
+// authHooksChecksum digests the hooks and the database connection they are
+// checked against.
+func authHooksChecksum(hooks []supabasev1alpha1.AuthHook, dbConfig database.InitConfig) string {
+	data, _ := json.Marshal(struct {
+		Hooks    []supabasev1alpha1.AuthHook `json:"hooks"`
+		DBConfig database.InitConfig         `json:"dbConfig"`
+	}{hooks, dbConfig})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// validateSMTPSecret checks the optional Auth SMTP secret and records the
+// outcome in the SMTPConfigured condition.
+func (r *SupabaseProjectReconciler) validateSMTPSecret(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Auth == nil || project.Spec.Auth.SMTPSecretRef == nil {
+		project.Status.Conditions = status.SetCondition(
+			project.Status.Conditions,
+			status.NewComponentCondition(status.ConditionTypeSMTPConfigured, metav1.ConditionFalse, "NotConfigured", "No SMTP secret configured, email signups are auto-confirmed"),
+		)
+		return nil
+	}
+
+	secretRef := project.Spec.Auth.SMTPSecretRef
+	namespace := secretRef.Namespace
+	if namespace == "" {
+		namespace = project.Namespace
+	}
+
+	smtpSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: secretRef.Name}, smtpSecret); err != nil {
+		project.Status.Conditions = status.SetCondition(
+			project.Status.Conditions,
+			status.NewComponentCondition(status.ConditionTypeSMTPConfigured, metav1.ConditionFalse, "SecretNotFound", err.Error()),
+		)
+		return fmt.Errorf("failed to get smtp secret: %w", err)
+	}
+
+	if err := secrets.ValidateSMTPSecret(smtpSecret); err != nil {
+		project.Status.Conditions = status.SetCondition(
+			project.Status.Conditions,
+			status.NewComponentCondition(status.ConditionTypeSMTPConfigured, metav1.ConditionFalse, "SecretInvalid", err.Error()),
+		)
+		return fmt.Errorf("smtp secret validation failed: %w", err)
+	}
+
+	project.Status.Conditions = status.SetCondition(
+		project.Status.Conditions,
+		status.NewComponentCondition(status.ConditionTypeSMTPConfigured, metav1.ConditionTrue, "SecretValid", "SMTP secret is valid"),
+	)
 	return nil
 }
 
@@ -438,24 +1082,84 @@ func (r *SupabaseProjectReconciler) ensureDatabaseInitJob(ctx context.Context, p
 func (r *SupabaseProjectReconciler) ensureJWTSecrets(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
 	secretName := project.Name + "-jwt"
 
+	// Keys added after the first release are backfilled into existing Secrets.
+	componentKeys := []secretGenerator{
+		{pgMetaCryptoKey, secrets.GeneratePGMetaCryptoKey},
+		{realtimeDBEncKey, secrets.GenerateRealtimeDBEncKey},
+		{realtimeSecretKeyBase, secrets.GenerateSecretKeyBase},
+		{component.PublishableKeyKey, secrets.GeneratePublishableKey},
+		{component.SecretKeyKey, secrets.GenerateSecretKey},
+	}
+
 	existingSecret := &corev1.Secret{}
 	err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: secretName}, existingSecret)
 
 	if err == nil {
-		if _, ok := existingSecret.Data[pgMetaCryptoKey]; ok {
-			return nil
+		if existingSecret.Data == nil {
+			existingSecret.Data = map[string][]byte{}
 		}
 
-		metaCryptoKey, genErr := secrets.GeneratePGMetaCryptoKey()
-		if genErr != nil {
-			return fmt.Errorf("failed to generate PG meta crypto key: %w", genErr)
+		changed := false
+		if jwtSecretRef(project) != nil {
+			supplied, err := r.suppliedJWTKeys(ctx, project, existingSecret.Data)
+			if err != nil {
+				return err
+			}
+			for key, value := range supplied {
+				if string(existingSecret.Data[key]) != value {
+					existingSecret.Data[key] = []byte(value)
+					changed = true
+				}
+			}
 		}
 
-		if existingSecret.Data == nil {
-			existingSecret.Data = map[string][]byte{}
+		signingKey, err := jwtSigningKey(project, existingSecret.Data[component.JWTSigningKeyKey])
+		if err != nil {
+			return err
 		}
-		existingSecret.Data[pgMetaCryptoKey] = []byte(metaCryptoKey)
+		if signingKey == "" {
+			if _, ok := existingSecret.Data[component.JWTSigningKeyKey]; ok {
+				delete(existingSecret.Data, component.JWTSigningKeyKey)
+				changed = true
+			}
+		} else if string(existingSecret.Data[component.JWTSigningKeyKey]) != signingKey {
+			existingSecret.Data[component.JWTSigningKeyKey] = []byte(signingKey)
+			changed = true
+		}
+
+		derivedChanged, err := updateDerivedJWTKeys(existingSecret.Data)
+		if err != nil {
+			return err
+		}
+		changed = changed || derivedChanged
+
+		for _, g := range componentKeys {
+			if _, ok := existingSecret.Data[g.key]; ok {
+				continue
+			}
+
+			// Installs from before the Realtime key was generated have tenants
+			// encrypted with the legacy key. Realtime re-seeds its tenant with
+			// the current key on startup, so only keep the legacy key when
+			// seeding has been turned off.
+			if g.key == realtimeDBEncKey && !realtimeSeedsTenant(project) {
+				existingSecret.Data[g.key] = []byte(realtimeLegacyDBEncKey)
+				r.Recorder.Event(project, corev1.EventTypeWarning, EventReasonRealtimeLegacyEncKey, EventMessageRealtimeLegacyEncKey)
+				changed = true
+				continue
+			}
 
+			value, genErr := g.generate()
+			if genErr != nil {
+				return fmt.Errorf("failed to generate %s: %w", g.key, genErr)
+			}
+			existingSecret.Data[g.key] = []byte(value)
+			changed = true
+		}
+
+		if !changed {
+			return nil
+		}
 		return r.Update(ctx, existingSecret)
 	}
 
@@ -463,24 +1167,315 @@ func (r *SupabaseProjectReconciler) ensureJWTSecrets(ctx context.Context, projec
 		return err
 	}
 
+	var keys map[string]string
+	if jwtSecretRef(project) != nil {
+		keys, err = r.suppliedJWTKeys(ctx, project, nil)
+	} else {
+		keys, err = generateJWTKeys(apiKeyTTL(project))
+	}
+	if err != nil {
+		return err
+	}
+
+	signingKey, err := jwtSigningKey(project, nil)
+	if err != nil {
+		return err
+	}
+	if signingKey != "" {
+		keys[component.JWTSigningKeyKey] = signingKey
+	}
+
+	data := map[string][]byte{}
+	for key, value := range keys {
+		data[key] = []byte(value)
+	}
+	derived, err := derivedJWTKeys(data)
+	if err != nil {
+		return err
+	}
+	for key, value := range derived {
+		keys[key] = value
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: project.Namespace,
+			// Rotations requested before the secret existed are already served.
+			Annotations: handledJWTRotationRequests(project),
+		},
+		StringData: keys,
+	}
+
+	for _, g := range componentKeys {
+		// Opaque API keys may already come from jwt.secretRef.
+		if _, ok := secret.StringData[g.key]; ok {
+			continue
+		}
+		value, genErr := g.generate()
+		if genErr != nil {
+			return fmt.Errorf("failed to generate %s: %w", g.key, genErr)
+		}
+		secret.StringData[g.key] = value
+	}
+
+	if err := controllerutil.SetControllerReference(project, secret, r.Scheme); err != nil {
+		return err
+	}
+
+	return r.Create(ctx, secret)
+}
+
+// jwtSigningKey returns the private JWK Auth signs access tokens with, or ""
+// when it signs them with the JWT secret. The current key is kept as long as
+// it matches spec.jwt.signingAlgorithm.
+func jwtSigningKey(project *supabasev1alpha1.SupabaseProject, current []byte) (string, error) {
+	if !component.AsymmetricJWTEnabled(project) {
+		return "", nil
+	}
+
+	algorithm := project.Spec.JWT.SigningAlgorithm
+	if len(current) > 0 {
+		if key, err := secrets.ParseJWK(string(current)); err == nil && key.Alg == algorithm {
+			return string(current), nil
+		}
+	}
+
+	key, err := secrets.GenerateSigningJWK(algorithm)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate JWT signing key: %w", err)
+	}
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT signing key: %w", err)
+	}
+	return string(encoded), nil
+}
+
+// generateJWTKeys returns a random JWT secret with anon and service role keys
+// signed by it that expire after ttl.
+func generateJWTKeys(ttl time.Duration) (map[string]string, error) {
 	jwtSecret, err := secrets.GenerateJWTSecret()
 	if err != nil {
-		return fmt.Errorf("failed to generate JWT secret: %w", err)
+		return nil, fmt.Errorf("failed to generate JWT secret: %w", err)
 	}
 
-	anonKey, err := secrets.GenerateAnonKey(jwtSecret)
+	anonKey, err := secrets.GenerateAPIKey(jwtSecret, "anon", ttl)
 	if err != nil {
-		return fmt.Errorf("failed to generate anon key: %w", err)
+		return nil, fmt.Errorf("failed to generate anon key: %w", err)
 	}
 
-	serviceRole, err := secrets.GenerateServiceRoleKey(jwtSecret)
+	serviceRole, err := secrets.GenerateAPIKey(jwtSecret, "service_role", ttl)
 	if err != nil {
-		return fmt.Errorf("failed to generate service role key: %w", err)
+		return nil, fmt.Errorf("failed to generate service role key: %w", err)
+	}
+
+	return map[string]string{
+		jwtSecretKey:   jwtSecret,
+		anonKeyKey:     anonKey,
+		serviceRoleKey: serviceRole,
+	}, nil
+}
+
+// jwtSecretRef returns the user-supplied JWT Secret reference, if any.
+func jwtSecretRef(project *supabasev1alpha1.SupabaseProject) *corev1.SecretReference {
+	if project.Spec.JWT == nil {
+		return nil
+	}
+	return project.Spec.JWT.SecretRef
+}
+
+// suppliedJWTKeys reads the JWT secret and API keys from the Secret referenced
+// by spec.jwt.secretRef. API keys the user did not supply are derived from the
+// secret; a key derived earlier is kept in current as long as it still
+// verifies, so that it does not change on every reconcile.
+func (r *SupabaseProjectReconciler) suppliedJWTKeys(ctx context.Context, project *supabasev1alpha1.SupabaseProject, current map[string][]byte) (map[string]string, error) {
+	ref := jwtSecretRef(project)
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = project.Namespace
+	}
+
+	userSecret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, userSecret); err != nil {
+		return nil, fmt.Errorf("failed to get JWT secret %s: %w", ref.Name, err)
+	}
+
+	jwtSecret := string(userSecret.Data[jwtSecretKey])
+	if err := secrets.ValidateJWTSecret(jwtSecret); err != nil {
+		return nil, fmt.Errorf("invalid %s in JWT secret %s: %w", jwtSecretKey, ref.Name, err)
+	}
+
+	keys := map[string]string{jwtSecretKey: jwtSecret}
+	apiKeys := []struct {
+		key  string
+		role string
+	}{
+		{anonKeyKey, "anon"},
+		{serviceRoleKey, "service_role"},
+	}
+
+	for _, k := range apiKeys {
+		if value := string(userSecret.Data[k.key]); value != "" {
+			if err := secrets.VerifyAPIKey(value, jwtSecret, k.role); err != nil {
+				return nil, fmt.Errorf("invalid %s in JWT secret %s: %w", k.key, ref.Name, err)
+			}
+			keys[k.key] = value
+			continue
+		}
+
+		if value := string(current[k.key]); value != "" && secrets.VerifyAPIKey(value, jwtSecret, k.role) == nil {
+			keys[k.key] = value
+			continue
+		}
+
+		value, err := secrets.GenerateAPIKey(jwtSecret, k.role, apiKeyTTL(project))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", k.key, err)
+		}
+		keys[k.key] = value
+	}
+
+	// Opaque API keys are not tied to the JWT secret; missing ones are
+	// generated like the other component keys.
+	opaqueKeys := []struct {
+		key    string
+		prefix string
+	}{
+		{component.PublishableKeyKey, secrets.PublishableKeyPrefix},
+		{component.SecretKeyKey, secrets.SecretKeyPrefix},
+	}
+	for _, k := range opaqueKeys {
+		value := string(userSecret.Data[k.key])
+		if value == "" {
+			continue
+		}
+		if err := secrets.ValidateOpaqueAPIKey(value, k.prefix); err != nil {
+			return nil, fmt.Errorf("invalid %s in JWT secret %s: %w", k.key, ref.Name, err)
+		}
+		keys[k.key] = value
+	}
+
+	return keys, nil
+}
+
+// projectsForJWTSecret enqueues the projects that copy their JWT secret from
+// the given Secret.
+func (r *SupabaseProjectReconciler) projectsForJWTSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	projects := &supabasev1alpha1.SupabaseProjectList{}
+	if err := r.List(ctx, projects); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list SupabaseProjects for JWT secret", "secret", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, project := range projects.Items {
+		ref := jwtSecretRef(&project)
+		if ref == nil || ref.Name != obj.GetName() {
+			continue
+		}
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = project.Namespace
+		}
+		if namespace == obj.GetNamespace() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&project)})
+		}
+	}
+	return requests
+}
+
+// readyAPIKeys returns the SupabaseAPIKeys of the project whose token has been
+// issued, for Kong to register as consumers.
+func (r *SupabaseProjectReconciler) readyAPIKeys(ctx context.Context, project *supabasev1alpha1.SupabaseProject) ([]component.KongAPIKey, error) {
+	apiKeys := &supabasev1alpha1.SupabaseAPIKeyList{}
+	if err := r.List(ctx, apiKeys, client.InNamespace(project.Namespace)); err != nil {
+		return nil, err
+	}
+
+	var ready []component.KongAPIKey
+	for _, apiKey := range apiKeys.Items {
+		if apiKey.Spec.ProjectRef.Name != project.Name || apiKey.DeletionTimestamp != nil ||
+			!meta.IsStatusConditionTrue(apiKey.Status.Conditions, status.ConditionTypeReady) {
+			continue
+		}
+		group := apiKey.Spec.Group
+		if group == "" {
+			group = supabasev1alpha1.APIKeyGroupAnon
+		}
+		ready = append(ready, component.KongAPIKey{
+			Name:       apiKey.Name,
+			Group:      group,
+			SecretName: apiKey.Status.SecretName,
+			KeyID:      apiKey.Status.KeyID,
+		})
+	}
+	return ready, nil
+}
+
+// projectForAPIKey maps a SupabaseAPIKey to the project it belongs to, so Kong
+// picks up issued, re-signed and deleted keys.
+func (r *SupabaseProjectReconciler) projectForAPIKey(ctx context.Context, obj client.Object) []reconcile.Request {
+	apiKey, ok := obj.(*supabasev1alpha1.SupabaseAPIKey)
+	if !ok || apiKey.Spec.ProjectRef.Name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: apiKey.Namespace, Name: apiKey.Spec.ProjectRef.Name}}}
+}
+
+// realtimeSeedsTenant reports whether Realtime recreates its self-hosted tenant
+// on startup, which is the default unless SEED_SELF_HOST is overridden.
+func realtimeSeedsTenant(project *supabasev1alpha1.SupabaseProject) bool {
+	if project.Spec.Realtime == nil {
+		return true
+	}
+	for _, env := range project.Spec.Realtime.ExtraEnv {
+		if env.Name == "SEED_SELF_HOST" {
+			return env.Value == "true"
+		}
+	}
+	return true
+}
+
+// ensureSAMLSecret generates the SAML signing key when SAML is enabled without
+// a user-supplied key. Like the JWT secret, a missing key is regenerated, so
+// deleting it from the Secret rotates it.
+func (r *SupabaseProjectReconciler) ensureSAMLSecret(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Auth == nil || project.Spec.Auth.SAML == nil || !project.Spec.Auth.SAML.Enabled ||
+		project.Spec.Auth.SAML.PrivateKeySecretRef != nil {
+		return nil
+	}
+
+	secretName := project.Name + "-saml"
+
+	existingSecret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: secretName}, existingSecret)
+
+	if err == nil {
+		if _, ok := existingSecret.Data[samlPrivateKey]; ok {
+			return nil
+		}
+
+		privateKey, genErr := secrets.GenerateSAMLPrivateKey()
+		if genErr != nil {
+			return fmt.Errorf("failed to generate SAML private key: %w", genErr)
+		}
+
+		if existingSecret.Data == nil {
+			existingSecret.Data = map[string][]byte{}
+		}
+		existingSecret.Data[samlPrivateKey] = []byte(privateKey)
+
+		return r.Update(ctx, existingSecret)
+	}
+
+	if !apierrors.IsNotFound(err) {
+		return err
 	}
 
-	metaCryptoKey, err := secrets.GeneratePGMetaCryptoKey()
+	privateKey, err := secrets.GenerateSAMLPrivateKey()
 	if err != nil {
-		return fmt.Errorf("failed to generate PG meta crypto key: %w", err)
+		return fmt.Errorf("failed to generate SAML private key: %w", err)
 	}
 
 	secret := &corev1.Secret{
@@ -489,10 +1484,7 @@ func (r *SupabaseProjectReconciler) ensureJWTSecrets(ctx context.Context, projec
 			Namespace: project.Namespace,
 		},
 		StringData: map[string]string{
-			jwtSecretKey:    jwtSecret,
-			anonKeyKey:      anonKey,
-			serviceRoleKey:  serviceRole,
-			pgMetaCryptoKey: metaCryptoKey,
+			samlPrivateKey: privateKey,
 		},
 	}
 
@@ -503,18 +1495,152 @@ func (r *SupabaseProjectReconciler) ensureJWTSecrets(ctx context.Context, projec
 	return r.Create(ctx, secret)
 }
 
+// samlKeyChecksum digests the SAML private key Auth loads, or returns "" when
+// SAML is disabled.
+func (r *SupabaseProjectReconciler) samlKeyChecksum(ctx context.Context, project *supabasev1alpha1.SupabaseProject) (string, error) {
+	ref, ok := component.SAMLPrivateKeyRef(project)
+	if !ok {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get SAML secret: %w", err)
+	}
+	return component.SAMLKeyChecksum(secret.Data[ref.Key]), nil
+}
+
+// projectsForSAMLSecret maps a Secret to the projects whose Auth loads their
+// SAML private key from it, so the Auth pods roll when the key changes.
+func (r *SupabaseProjectReconciler) projectsForSAMLSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	projects := &supabasev1alpha1.SupabaseProjectList{}
+	if err := r.List(ctx, projects, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list SupabaseProjects for SAML secret", "secret", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, project := range projects.Items {
+		if ref, ok := component.SAMLPrivateKeyRef(&project); ok && ref.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&project)})
+		}
+	}
+	return requests
+}
+
+// secretGenerator produces the value of one key of an operator-generated Secret.
+type secretGenerator struct {
+	key      string
+	generate func() (string, error)
+}
+
+// ensurePoolerSecret generates the Supavisor SECRET_KEY_BASE and VAULT_ENC_KEY
+// when the pooler is enabled. Missing keys are regenerated. The vault key
+// encrypts tenant credentials in _supabase, so it is never rotated in place.
+func (r *SupabaseProjectReconciler) ensurePoolerSecret(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if !component.PoolerEnabled(project) {
+		return nil
+	}
+
+	return r.ensureGeneratedSecret(ctx, project, project.Name+"-pooler", "pooler", []secretGenerator{
+		{component.PoolerSecretKeyBase, secrets.GenerateSecretKeyBase},
+		{component.PoolerVaultEncKey, secrets.GenerateVaultEncKey},
+	})
+}
+
+// ensureAnalyticsSecret generates the Logflare access tokens shared by
+// Logflare, Vector and Studio when analytics is enabled.
+func (r *SupabaseProjectReconciler) ensureAnalyticsSecret(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if !component.AnalyticsEnabled(project) {
+		return nil
+	}
+
+	return r.ensureGeneratedSecret(ctx, project, project.Name+"-analytics", "analytics", []secretGenerator{
+		{component.AnalyticsPublicAccessToken, secrets.GenerateAccessToken},
+		{component.AnalyticsPrivateAccessToken, secrets.GenerateAccessToken},
+	})
+}
+
+// ensureGeneratedSecret creates the named Secret owned by the project and
+// fills in any key that is missing. Existing values are never replaced.
+func (r *SupabaseProjectReconciler) ensureGeneratedSecret(ctx context.Context, project *supabasev1alpha1.SupabaseProject, secretName, purpose string, generators []secretGenerator) error {
+	existingSecret := &corev1.Secret{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: secretName}, existingSecret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	found := err == nil
+
+	if !found {
+		existingSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: project.Namespace,
+			},
+		}
+	}
+	if existingSecret.Data == nil {
+		existingSecret.Data = map[string][]byte{}
+	}
+
+	changed := false
+	for _, g := range generators {
+		if _, ok := existingSecret.Data[g.key]; ok {
+			continue
+		}
+		value, genErr := g.generate()
+		if genErr != nil {
+			return fmt.Errorf("failed to generate %s %s: %w", purpose, g.key, genErr)
+		}
+		existingSecret.Data[g.key] = []byte(value)
+		changed = true
+	}
+
+	if found {
+		if !changed {
+			return nil
+		}
+		return r.Update(ctx, existingSecret)
+	}
+
+	if err := controllerutil.SetControllerReference(project, existingSecret, r.Scheme); err != nil {
+		return err
+	}
+	return r.Create(ctx, existingSecret)
+}
+
 func (r *SupabaseProjectReconciler) handleDeletion(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	r.authHookChecks.Delete(project.UID)
 	return nil
 }
 
 func (r *SupabaseProjectReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&supabasev1alpha1.SupabaseProject{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.DaemonSet{}).
 		Owns(&batchv1.Job{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&networkingv1.Ingress{}).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.projectsForFunctionsConfigMap)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.projectsForJWTSecret)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.projectsForSAMLSecret)).
+		Watches(&supabasev1alpha1.SupabaseAPIKey{}, handler.EnqueueRequestsFromMapFunc(r.projectForAPIKey))
+
+	// Gateway API CRDs are optional; only watch the kinds the cluster serves.
+	for kind, obj := range map[string]client.Object{
+		"HTTPRoute":        &gatewayv1.HTTPRoute{},
+		"BackendTLSPolicy": &gatewayv1.BackendTLSPolicy{},
+	} {
+		gk := schema.GroupKind{Group: gatewayv1.GroupName, Kind: kind}
+		if _, err := mgr.GetRESTMapper().RESTMapping(gk, gatewayv1.GroupVersion.Version); err == nil {
+			builder = builder.Owns(obj)
+		}
+	}
+
+	return builder.
 		Named("supabaseproject").
 		Complete(r)
 }
@@ -2,11 +2,13 @@ package controller
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -14,6 +16,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/component"
+	"github.com/strrl/supabase-operator/internal/database"
+	"github.com/strrl/supabase-operator/internal/secrets"
+	"github.com/strrl/supabase-operator/internal/status"
 )
 
 var _ = Describe("SupabaseProject Controller", func() {
@@ -131,4 +137,110 @@ var _ = Describe("SupabaseProject Controller", func() {
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
 	})
+
+	Context("When validating auth hooks", func() {
+		ctx := context.Background()
+
+		It("should only query the database when the hooks or the database Secret change", func() {
+			reconciler := &SupabaseProjectReconciler{}
+			project := &supabasev1alpha1.SupabaseProject{
+				ObjectMeta: metav1.ObjectMeta{Name: "hooks", Namespace: "default", UID: "hooks-uid"},
+				Spec: supabasev1alpha1.SupabaseProjectSpec{
+					Auth: &supabasev1alpha1.AuthConfig{
+						Hooks: []supabasev1alpha1.AuthHook{
+							{Type: "custom_access_token", URI: "pg-functions://postgres/public/custom_access_token_hook"},
+						},
+					},
+				},
+			}
+			// Nothing listens on this port, so every query fails.
+			dbSecret := &corev1.Secret{Data: map[string][]byte{
+				"host": []byte("127.0.0.1"), "port": []byte("1"), "database": []byte("postgres"),
+				"username": []byte("postgres"), "password": []byte("postgres"),
+			}}
+
+			Expect(reconciler.validateAuthHooks(ctx, project, dbSecret)).NotTo(Succeed())
+			Expect(meta.IsStatusConditionFalse(project.Status.Conditions, status.ConditionTypeAuthHooksReady)).To(BeTrue())
+
+			By("caching a successful check")
+			dbConfig := database.InitConfig{
+				Host: "127.0.0.1", Port: "1", Database: "postgres", Username: "postgres", Password: "postgres",
+				SSLMode: component.DatabaseSSLMode(project),
+			}
+			reconciler.authHookChecks.Store(project.UID, authHooksChecksum(project.Spec.Auth.Hooks, dbConfig))
+			project.Status.Conditions = status.SetCondition(project.Status.Conditions,
+				status.NewComponentCondition(status.ConditionTypeAuthHooksReady, metav1.ConditionTrue, "HooksValid", "1 auth hook(s) configured"))
+			Expect(reconciler.validateAuthHooks(ctx, project, dbSecret)).To(Succeed())
+
+			By("changing the hook")
+			project.Spec.Auth.Hooks[0].URI = "pg-functions://postgres/public/other_hook"
+			Expect(reconciler.validateAuthHooks(ctx, project, dbSecret)).NotTo(Succeed())
+
+			By("changing the database Secret")
+			project.Spec.Auth.Hooks[0].URI = "pg-functions://postgres/public/custom_access_token_hook"
+			project.Status.Conditions = status.SetCondition(project.Status.Conditions,
+				status.NewComponentCondition(status.ConditionTypeAuthHooksReady, metav1.ConditionTrue, "HooksValid", "1 auth hook(s) configured"))
+			dbSecret.Data["password"] = []byte("rotated")
+			Expect(reconciler.validateAuthHooks(ctx, project, dbSecret)).NotTo(Succeed())
+		})
+	})
+
+	Context("When re-minting API keys", func() {
+		const projectName = "test-api-key-expiry"
+
+		ctx := context.Background()
+
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: projectName + "-jwt", Namespace: "default"},
+			})).To(Succeed())
+		})
+
+		It("should wait for a running grace period to end", func() {
+			recorder := record.NewFakeRecorder(100)
+			reconciler := &SupabaseProjectReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Recorder: recorder}
+			project := &supabasev1alpha1.SupabaseProject{
+				ObjectMeta: metav1.ObjectMeta{Name: projectName, Namespace: "default"},
+			}
+
+			jwtSecret, err := secrets.GenerateJWTSecret()
+			Expect(err).NotTo(HaveOccurred())
+			// Both keys are within the default renewal threshold.
+			anonKey, err := secrets.GenerateAPIKey(jwtSecret, "anon", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			serviceKey, err := secrets.GenerateAPIKey(jwtSecret, "service_role", time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      projectName + "-jwt",
+					Namespace: "default",
+					Annotations: map[string]string{
+						jwtPreviousExpiresAtAnnotation: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+					},
+				},
+				Data: map[string][]byte{
+					jwtSecretKey:                        []byte(jwtSecret),
+					anonKeyKey:                          []byte(anonKey),
+					serviceRoleKey:                      []byte(serviceKey),
+					component.JWTPreviousAnonKey:        []byte("previous-anon"),
+					component.JWTPreviousServiceRoleKey: []byte("previous-service-role"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			Expect(reconciler.reconcileAPIKeyExpiry(ctx, project, secret)).To(Succeed())
+			Expect(string(secret.Data[anonKeyKey])).To(Equal(anonKey))
+			Expect(string(secret.Data[component.JWTPreviousAnonKey])).To(Equal("previous-anon"))
+			Expect(recorder.Events).To(BeEmpty())
+
+			By("ending the grace period")
+			delete(secret.Annotations, jwtPreviousExpiresAtAnnotation)
+			Expect(reconciler.reconcileAPIKeyExpiry(ctx, project, secret)).To(Succeed())
+			Expect(string(secret.Data[anonKeyKey])).NotTo(Equal(anonKey))
+			Expect(string(secret.Data[component.JWTPreviousAnonKey])).To(Equal(anonKey))
+			Expect(string(secret.Data[component.JWTPreviousServiceRoleKey])).To(Equal(serviceKey))
+			Expect(secret.Annotations).To(HaveKey(jwtPreviousExpiresAtAnnotation))
+			Expect(<-recorder.Events).To(HavePrefix(corev1.EventTypeNormal + " " + EventReasonAPIKeysReminted))
+		})
+	})
 })
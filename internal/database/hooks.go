@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// PGFunctionsScheme is the URI scheme GoTrue uses for Postgres function hooks.
+const PGFunctionsScheme = "pg-functions"
+
+// ParsePGFunctionURI splits a pg-functions://<database>/<schema>/<function>
+// hook URI into its schema and function name.
+func ParsePGFunctionURI(uri string) (string, string, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid hook uri: %w", err)
+	}
+	if parsed.Scheme != PGFunctionsScheme {
+		return "", "", fmt.Errorf("hook uri must use the %s scheme", PGFunctionsScheme)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if parsed.Host == "" || len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("hook uri must have the form %s://<database>/<schema>/<function>", PGFunctionsScheme)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// FunctionExists reports whether a function with the given name exists in the
+// schema.
+func FunctionExists(ctx context.Context, config InitConfig, schema, function string) (bool, error) {
+	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		config.Host, config.Port, config.Username, config.Password, config.Database, config.SSLMode)
+
+	conn, err := pgx.Connect(ctx, connStr)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() {
+		_ = conn.Close(ctx)
+	}()
+
+	var exists bool
+	err = conn.QueryRow(ctx,
+		`SELECT EXISTS (
+			SELECT 1 FROM pg_proc p
+			JOIN pg_namespace n ON n.oid = p.pronamespace
+			WHERE n.nspname = $1 AND p.proname = $2
+		)`, schema, function).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up function %s.%s: %w", schema, function, err)
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import "testing"
+
+func TestParsePGFunctionURI(t *testing.T) {
+	tests := []struct {
+		name         string
+		uri          string
+		wantSchema   string
+		wantFunction string
+		wantErr      bool
+	}{
+		{
+			name:         "valid uri",
+			uri:          "pg-functions://postgres/public/custom_access_token_hook",
+			wantSchema:   "public",
+			wantFunction: "custom_access_token_hook",
+		},
+		{
+			name:    "http uri",
+			uri:     "https://hooks.example.com/send-email",
+			wantErr: true,
+		},
+		{
+			name:    "missing function",
+			uri:     "pg-functions://postgres/public",
+			wantErr: true,
+		},
+		{
+			name:    "missing database",
+			uri:     "pg-functions:///public/hook",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, function, err := ParsePGFunctionURI(tt.uri)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePGFunctionURI() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if schema != tt.wantSchema || function != tt.wantFunction {
+				t.Errorf("ParsePGFunctionURI() = %s.%s, want %s.%s", schema, function, tt.wantSchema, tt.wantFunction)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Prefixes of the opaque API keys. Kong swaps them for the anon and service
+// role JWTs before requests reach the other components.
+const (
+	PublishableKeyPrefix = "sb_publishable_"
+	SecretKeyPrefix      = "sb_secret_"
+)
+
+// GeneratePublishableKey returns an opaque API key that replaces the anon key
+// in browsers and apps.
+func GeneratePublishableKey() (string, error) {
+	return generateOpaqueAPIKey(PublishableKeyPrefix)
+}
+
+// GenerateSecretKey returns an opaque API key that replaces the service role
+// key in trusted backends.
+func GenerateSecretKey() (string, error) {
+	return generateOpaqueAPIKey(SecretKeyPrefix)
+}
+
+// ValidateOpaqueAPIKey checks that key carries prefix and that its remainder
+// only uses URL-safe characters, since Kong embeds the key in its Lua
+// expressions.
+func ValidateOpaqueAPIKey(key, prefix string) error {
+	body, ok := strings.CutPrefix(key, prefix)
+	if !ok {
+		return fmt.Errorf("key must start with %q", prefix)
+	}
+	if body == "" {
+		return fmt.Errorf("key cannot be only the %q prefix", prefix)
+	}
+	for _, c := range body {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return fmt.Errorf("key contains invalid character %q", c)
+		}
+	}
+	return nil
+}
+
+func generateOpaqueAPIKey(prefix string) (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(bytes), nil
+}
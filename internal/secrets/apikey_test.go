@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateOpaqueAPIKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() (string, error)
+		prefix   string
+	}{
+		{"publishable", GeneratePublishableKey, PublishableKeyPrefix},
+		{"secret", GenerateSecretKey, SecretKeyPrefix},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := tt.generate()
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+			if !strings.HasPrefix(key, tt.prefix) {
+				t.Errorf("key %q does not start with %q", key, tt.prefix)
+			}
+			if err := ValidateOpaqueAPIKey(key, tt.prefix); err != nil {
+				t.Errorf("ValidateOpaqueAPIKey() error = %v", err)
+			}
+
+			other, _ := tt.generate()
+			if key == other {
+				t.Error("generate() returned the same key twice")
+			}
+		})
+	}
+}
+
+func TestValidateOpaqueAPIKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		prefix  string
+		wantErr bool
+	}{
+		{"valid publishable", "sb_publishable_ACJWlzQHlZjBrEguHvfOxg_3BJgxAaH", PublishableKeyPrefix, false},
+		{"valid secret", "sb_secret_N7UND0UgjKTVK-Uodkm0Hg_xSvEMPvz", SecretKeyPrefix, false},
+		{"wrong prefix", "sb_secret_N7UND0UgjKTVK", PublishableKeyPrefix, true},
+		{"legacy jwt", "eyJhbGciOiJIUzI1NiJ9.e30.sig", SecretKeyPrefix, true},
+		{"prefix only", "sb_secret_", SecretKeyPrefix, true},
+		{"quote", "sb_secret_abc'def", SecretKeyPrefix, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateOpaqueAPIKey(tt.key, tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOpaqueAPIKey() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
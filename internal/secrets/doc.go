@@ -23,6 +23,9 @@
 //	anonKey, err := secrets.GenerateAnonKey(jwtSecret)
 //	serviceKey, err := secrets.GenerateServiceRoleKey(jwtSecret)
 //
+// When SAML SSO is enabled without a user-supplied key, a 2048-bit RSA key is
+// generated with GenerateSAMLPrivateKey (base64-encoded PKCS#1 DER).
+//
 // Secret Validation:
 //
 // The package validates user-provided secrets contain required keys:
@@ -41,6 +44,9 @@
 //   - accessKeyId: S3 access key ID
 //   - secretAccessKey: S3 secret access key
 //
+// SMTP secret (optional) must contain:
+//   - host, port, username, password, from
+//
 // Example usage:
 //
 //	err := secrets.ValidateDatabaseSecret(secret)
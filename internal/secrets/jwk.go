@@ -0,0 +1,162 @@
+package secrets
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Asymmetric algorithms Auth can sign access tokens with.
+const (
+	AlgorithmES256 = "ES256"
+	AlgorithmRS256 = "RS256"
+)
+
+// JWK is a JSON Web Key (RFC 7517) of type oct, EC or RSA.
+type JWK struct {
+	Kty    string   `json:"kty"`
+	Kid    string   `json:"kid,omitempty"`
+	Alg    string   `json:"alg,omitempty"`
+	Use    string   `json:"use,omitempty"`
+	KeyOps []string `json:"key_ops,omitempty"`
+
+	// Symmetric key.
+	K string `json:"k,omitempty"`
+
+	// Elliptic curve public key.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+
+	// RSA public key.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Private key members.
+	D  string `json:"d,omitempty"`
+	P  string `json:"p,omitempty"`
+	Q  string `json:"q,omitempty"`
+	DP string `json:"dp,omitempty"`
+	DQ string `json:"dq,omitempty"`
+	QI string `json:"qi,omitempty"`
+}
+
+// Public returns the key without its private members, for publishing in a
+// JWKS.
+func (k JWK) Public() JWK {
+	public := k
+	public.D, public.P, public.Q, public.DP, public.DQ, public.QI = "", "", "", "", "", ""
+	public.KeyOps = []string{"verify"}
+	return public
+}
+
+// GenerateSigningJWK returns a new private signing key for alg as a JWK.
+func GenerateSigningJWK(alg string) (JWK, error) {
+	kid, err := generateRandomHex(16)
+	if err != nil {
+		return JWK{}, err
+	}
+
+	key := JWK{
+		Kid:    kid,
+		Alg:    alg,
+		Use:    "sig",
+		KeyOps: []string{"sign", "verify"},
+	}
+
+	switch alg {
+	case AlgorithmES256:
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return JWK{}, fmt.Errorf("failed to generate ecdsa key: %w", err)
+		}
+		d, err := privateKey.Bytes()
+		if err != nil {
+			return JWK{}, fmt.Errorf("failed to encode ecdsa key: %w", err)
+		}
+		point, err := privateKey.PublicKey.Bytes()
+		if err != nil {
+			return JWK{}, fmt.Errorf("failed to encode ecdsa key: %w", err)
+		}
+		// point is the uncompressed 0x04 || X || Y encoding.
+		size := (len(point) - 1) / 2
+		key.Kty = "EC"
+		key.Crv = "P-256"
+		key.X = base64URL(point[1 : 1+size])
+		key.Y = base64URL(point[1+size:])
+		key.D = base64URL(d)
+
+	case AlgorithmRS256:
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return JWK{}, fmt.Errorf("failed to generate rsa key: %w", err)
+		}
+		key.Kty = "RSA"
+		key.N = base64URL(privateKey.N.Bytes())
+		key.E = base64URL(big.NewInt(int64(privateKey.E)).Bytes())
+		key.D = base64URL(privateKey.D.Bytes())
+		key.P = base64URL(privateKey.Primes[0].Bytes())
+		key.Q = base64URL(privateKey.Primes[1].Bytes())
+		key.DP = base64URL(privateKey.Precomputed.Dp.Bytes())
+		key.DQ = base64URL(privateKey.Precomputed.Dq.Bytes())
+		key.QI = base64URL(privateKey.Precomputed.Qinv.Bytes())
+
+	default:
+		return JWK{}, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+
+	return key, nil
+}
+
+// HMACJWK returns the JWK of a base64 encoded HS256 JWT secret. The key can
+// only verify tokens, and its kid is derived from the secret so that it is
+// stable across calls.
+func HMACJWK(jwtSecret string) (JWK, error) {
+	decoded, err := base64.StdEncoding.DecodeString(jwtSecret)
+	if err != nil {
+		return JWK{}, fmt.Errorf("failed to decode jwt secret: %w", err)
+	}
+	sum := sha256.Sum256(decoded)
+
+	return JWK{
+		Kty:    "oct",
+		Kid:    hex.EncodeToString(sum[:8]),
+		Alg:    jwt.SigningMethodHS256.Alg(),
+		KeyOps: []string{"verify"},
+		K:      base64URL(decoded),
+	}, nil
+}
+
+// ParseJWK decodes a JWK from its JSON form.
+func ParseJWK(data string) (JWK, error) {
+	var key JWK
+	if err := json.Unmarshal([]byte(data), &key); err != nil {
+		return JWK{}, fmt.Errorf("failed to decode jwk: %w", err)
+	}
+	return key, nil
+}
+
+// EncodeJWKS returns the JSON Web Key Set holding keys.
+func EncodeJWKS(keys ...JWK) (string, error) {
+	jwks, err := json.Marshal(struct {
+		Keys []JWK `json:"keys"`
+	}{keys})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode jwks: %w", err)
+	}
+
+	return string(jwks), nil
+}
+
+func base64URL(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,164 @@
+package secrets
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestHMACJWK(t *testing.T) {
+	secret, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+
+	key, err := HMACJWK(secret)
+	if err != nil {
+		t.Fatalf("HMACJWK() error = %v", err)
+	}
+
+	if key.Kty != "oct" || key.Alg != "HS256" {
+		t.Errorf("HMACJWK() = %s/%s, want oct/HS256", key.Kty, key.Alg)
+	}
+	decoded, _ := base64.StdEncoding.DecodeString(secret)
+	if key.K != base64.RawURLEncoding.EncodeToString(decoded) {
+		t.Error("HMACJWK() does not hold the decoded secret")
+	}
+
+	again, _ := HMACJWK(secret)
+	if key.Kid == "" || key.Kid != again.Kid {
+		t.Errorf("HMACJWK() kid = %q then %q, want a stable non-empty kid", key.Kid, again.Kid)
+	}
+
+	if _, err := HMACJWK("invalid-base64!"); err == nil {
+		t.Error("HMACJWK() with invalid secret should return error")
+	}
+}
+
+func TestGenerateSigningJWK(t *testing.T) {
+	tests := []struct {
+		alg    string
+		kty    string
+		method jwt.SigningMethod
+	}{
+		{AlgorithmES256, "EC", jwt.SigningMethodES256},
+		{AlgorithmRS256, "RSA", jwt.SigningMethodRS256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alg, func(t *testing.T) {
+			key, err := GenerateSigningJWK(tt.alg)
+			if err != nil {
+				t.Fatalf("GenerateSigningJWK() error = %v", err)
+			}
+			if key.Kty != tt.kty || key.Alg != tt.alg || key.Kid == "" || key.D == "" {
+				t.Fatalf("GenerateSigningJWK() = %+v, want a private %s key with a kid", key, tt.kty)
+			}
+
+			public := key.Public()
+			if public.D != "" || public.P != "" || public.Q != "" {
+				t.Error("Public() kept private key members")
+			}
+
+			privateKey, publicKey := parseTestJWK(t, key)
+			token, err := jwt.NewWithClaims(tt.method, jwt.MapClaims{"role": "authenticated"}).SignedString(privateKey)
+			if err != nil {
+				t.Fatalf("SignedString() error = %v", err)
+			}
+			_, err = jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return publicKey, nil },
+				jwt.WithValidMethods([]string{tt.alg}))
+			if err != nil {
+				t.Errorf("token signed with the private key does not verify with the public key: %v", err)
+			}
+		})
+	}
+
+	if _, err := GenerateSigningJWK("HS512"); err == nil {
+		t.Error("GenerateSigningJWK() with an unsupported algorithm should return error")
+	}
+}
+
+func TestEncodeJWKS(t *testing.T) {
+	signing, err := GenerateSigningJWK(AlgorithmES256)
+	if err != nil {
+		t.Fatalf("GenerateSigningJWK() error = %v", err)
+	}
+	secret, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+	hmac, err := HMACJWK(secret)
+	if err != nil {
+		t.Fatalf("HMACJWK() error = %v", err)
+	}
+
+	jwks, err := EncodeJWKS(signing.Public(), hmac)
+	if err != nil {
+		t.Fatalf("EncodeJWKS() error = %v", err)
+	}
+
+	var set struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(jwks), &set); err != nil {
+		t.Fatalf("EncodeJWKS() returned invalid JSON: %v", err)
+	}
+	if len(set.Keys) != 2 {
+		t.Fatalf("EncodeJWKS() returned %d keys, want 2", len(set.Keys))
+	}
+	if set.Keys[0].Kid != signing.Kid || set.Keys[0].D != "" {
+		t.Errorf("first key = %+v, want the public signing key", set.Keys[0])
+	}
+	if set.Keys[1].K != hmac.K {
+		t.Errorf("second key = %+v, want the HS256 key", set.Keys[1])
+	}
+
+	parsed, err := ParseJWK(`{"kty":"EC","kid":"a","crv":"P-256"}`)
+	if err != nil || parsed.Kid != "a" || parsed.Crv != "P-256" {
+		t.Errorf("ParseJWK() = %+v, %v", parsed, err)
+	}
+}
+
+func parseTestJWK(t *testing.T, key JWK) (private, public interface{}) {
+	t.Helper()
+
+	decode := func(value string) []byte {
+		decoded, err := base64.RawURLEncoding.DecodeString(value)
+		if err != nil {
+			t.Fatalf("failed to decode JWK member: %v", err)
+		}
+		return decoded
+	}
+
+	switch key.Kty {
+	case "EC":
+		privateKey, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), decode(key.D))
+		if err != nil {
+			t.Fatalf("ParseRawPrivateKey() error = %v", err)
+		}
+		point := append([]byte{4}, append(decode(key.X), decode(key.Y)...)...)
+		publicKey, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			t.Fatalf("ParseUncompressedPublicKey() error = %v", err)
+		}
+		return privateKey, publicKey
+	default:
+		publicKey := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(decode(key.N)),
+			E: int(new(big.Int).SetBytes(decode(key.E)).Int64()),
+		}
+		privateKey := &rsa.PrivateKey{
+			PublicKey: *publicKey,
+			D:         new(big.Int).SetBytes(decode(key.D)),
+			Primes:    []*big.Int{new(big.Int).SetBytes(decode(key.P)), new(big.Int).SetBytes(decode(key.Q))},
+		}
+		privateKey.Precompute()
+		return privateKey, publicKey
+	}
+}
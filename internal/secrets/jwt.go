@@ -3,7 +3,10 @@ package secrets
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -17,7 +20,43 @@ func GeneratePGMetaCryptoKey() (string, error) {
 	return generateRandomBase64(32)
 }
 
+// GenerateSecretKeyBase returns a Phoenix SECRET_KEY_BASE, which must be at
+// least 64 characters long.
+func GenerateSecretKeyBase() (string, error) {
+	return generateRandomBase64(48)
+}
+
+// GenerateVaultEncKey returns a 32 character key for Supavisor's VAULT_ENC_KEY.
+func GenerateVaultEncKey() (string, error) {
+	return generateRandomHex(16)
+}
+
+// GenerateRealtimeDBEncKey returns a 16 character key for Realtime's
+// DB_ENC_KEY, which is used as an AES-128 key.
+func GenerateRealtimeDBEncKey() (string, error) {
+	return generateRandomHex(8)
+}
+
+// GenerateAccessToken returns a URL-safe token for Logflare's public and
+// private access tokens.
+func GenerateAccessToken() (string, error) {
+	return generateRandomHex(32)
+}
+
+// DefaultAPIKeyTTL is the lifetime of API keys when spec.jwt.apiKeyTTL is not
+// set.
+const DefaultAPIKeyTTL = 10 * 365 * 24 * time.Hour
+
 func GenerateAnonKey(jwtSecret string) (string, error) {
+	return GenerateAPIKey(jwtSecret, "anon", DefaultAPIKeyTTL)
+}
+
+func GenerateServiceRoleKey(jwtSecret string) (string, error) {
+	return GenerateAPIKey(jwtSecret, "service_role", DefaultAPIKeyTTL)
+}
+
+// GenerateAPIKey returns an HS256 API key for role that expires after ttl.
+func GenerateAPIKey(jwtSecret, role string, ttl time.Duration) (string, error) {
 	if jwtSecret == "" {
 		return "", fmt.Errorf("jwt secret cannot be empty")
 	}
@@ -29,10 +68,10 @@ func GenerateAnonKey(jwtSecret string) (string, error) {
 
 	now := time.Now()
 	claims := jwt.MapClaims{
-		"role": "anon",
+		"role": role,
 		"iss":  "supabase",
 		"iat":  now.Unix(),
-		"exp":  now.Add(10 * 365 * 24 * time.Hour).Unix(),
+		"exp":  now.Add(ttl).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -45,32 +84,141 @@ func GenerateAnonKey(jwtSecret string) (string, error) {
 	return tokenString, nil
 }
 
-func GenerateServiceRoleKey(jwtSecret string) (string, error) {
+// ReservedAPIKeyClaims are the claims SignNamedAPIKey sets itself.
+var ReservedAPIKeyClaims = []string{"role", "iss", "iat", "exp", "jti"}
+
+// SignNamedAPIKey returns an HS256 API key for role carrying the extra claims
+// and a random jti, which it also returns. A zero expiresAt leaves out the exp
+// claim.
+func SignNamedAPIKey(jwtSecret, role string, claims map[string]string, expiresAt time.Time) (key, keyID string, err error) {
+	decoded, err := base64.StdEncoding.DecodeString(jwtSecret)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode jwt secret: %w", err)
+	}
+
+	keyID, err = generateRandomHex(16)
+	if err != nil {
+		return "", "", err
+	}
+
+	tokenClaims := jwt.MapClaims{}
+	for name, value := range claims {
+		if slices.Contains(ReservedAPIKeyClaims, name) {
+			return "", "", fmt.Errorf("claim %q is set by the operator", name)
+		}
+		tokenClaims[name] = value
+	}
+	tokenClaims["role"] = role
+	tokenClaims["iss"] = "supabase"
+	tokenClaims["iat"] = time.Now().Unix()
+	tokenClaims["jti"] = keyID
+	if !expiresAt.IsZero() {
+		tokenClaims["exp"] = expiresAt.Unix()
+	}
+
+	key, err = jwt.NewWithClaims(jwt.SigningMethodHS256, tokenClaims).SignedString(decoded)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return key, keyID, nil
+}
+
+// APIKeyExpiry returns the exp claim of an API key without verifying its
+// signature. The zero time means the key never expires.
+func APIKeyExpiry(key string) (time.Time, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(key, claims); err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse api key: %w", err)
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read exp claim: %w", err)
+	}
+	if exp == nil {
+		return time.Time{}, nil
+	}
+	return exp.Time, nil
+}
+
+// APIKeyID returns the jti claim of key without verifying its signature, or ""
+// when it has none.
+func APIKeyID(key string) (string, error) {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(key, claims); err != nil {
+		return "", fmt.Errorf("failed to parse api key: %w", err)
+	}
+	keyID, _ := claims["jti"].(string)
+	return keyID, nil
+}
+
+// ValidateJWTSecret checks that a user-supplied JWT secret can be used to sign
+// keys. Like the generated secret, it must be base64 encoded.
+func ValidateJWTSecret(jwtSecret string) error {
 	if jwtSecret == "" {
-		return "", fmt.Errorf("jwt secret cannot be empty")
+		return fmt.Errorf("jwt secret cannot be empty")
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(jwtSecret); err != nil {
+		return fmt.Errorf("jwt secret must be base64 encoded: %w", err)
 	}
 
+	return nil
+}
+
+// VerifyAPIKey checks that key is an HS256 token signed with jwtSecret whose
+// role claim matches role.
+func VerifyAPIKey(key, jwtSecret, role string) error {
 	decoded, err := base64.StdEncoding.DecodeString(jwtSecret)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode jwt secret: %w", err)
+		return fmt.Errorf("failed to decode jwt secret: %w", err)
 	}
 
-	now := time.Now()
-	claims := jwt.MapClaims{
-		"role": "service_role",
-		"iss":  "supabase",
-		"iat":  now.Unix(),
-		"exp":  now.Add(10 * 365 * 24 * time.Hour).Unix(),
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(key, claims, func(*jwt.Token) (any, error) {
+		return decoded, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return fmt.Errorf("failed to verify token: %w", err)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if claims["role"] != role {
+		return fmt.Errorf("token role is %v, expected %s", claims["role"], role)
+	}
 
-	tokenString, err := token.SignedString(decoded)
+	return nil
+}
+
+// BuildJWKS returns a JSON Web Key Set with one HS256 key per JWT secret. The
+// secrets are base64 encoded, like the ones GenerateJWTSecret returns.
+func BuildJWKS(jwtSecrets ...string) (string, error) {
+	type jwk struct {
+		Kty string `json:"kty"`
+		Alg string `json:"alg"`
+		K   string `json:"k"`
+	}
+
+	keys := make([]jwk, 0, len(jwtSecrets))
+	for _, jwtSecret := range jwtSecrets {
+		decoded, err := base64.StdEncoding.DecodeString(jwtSecret)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode jwt secret: %w", err)
+		}
+		keys = append(keys, jwk{
+			Kty: "oct",
+			Alg: jwt.SigningMethodHS256.Alg(),
+			K:   base64.RawURLEncoding.EncodeToString(decoded),
+		})
+	}
+
+	jwks, err := json.Marshal(struct {
+		Keys []jwk `json:"keys"`
+	}{keys})
 	if err != nil {
-		return "", fmt.Errorf("failed to sign token: %w", err)
+		return "", fmt.Errorf("failed to encode jwks: %w", err)
 	}
 
-	return tokenString, nil
+	return string(jwks), nil
 }
 
 func generateRandomBase64(length int) (string, error) {
@@ -80,3 +228,11 @@ func generateRandomBase64(length int) (string, error) {
 	}
 	return base64.StdEncoding.EncodeToString(bytes), nil
 }
+
+func generateRandomHex(length int) (string, error) {
+	bytes := make([]byte, length)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -2,7 +2,10 @@ package secrets
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -65,6 +68,63 @@ func TestGeneratePGMetaCryptoKey(t *testing.T) {
 	}
 }
 
+func TestGenerateSecretKeyBase(t *testing.T) {
+	key, err := GenerateSecretKeyBase()
+	if err != nil {
+		t.Fatalf("GenerateSecretKeyBase() error = %v", err)
+	}
+
+	if len(key) < 64 {
+		t.Errorf("GenerateSecretKeyBase() length = %d, want at least 64", len(key))
+	}
+}
+
+func TestGenerateVaultEncKey(t *testing.T) {
+	key, err := GenerateVaultEncKey()
+	if err != nil {
+		t.Fatalf("GenerateVaultEncKey() error = %v", err)
+	}
+
+	if len(key) != 32 {
+		t.Errorf("GenerateVaultEncKey() length = %d, want 32", len(key))
+	}
+
+	key2, err := GenerateVaultEncKey()
+	if err != nil {
+		t.Fatalf("GenerateVaultEncKey() second call error = %v", err)
+	}
+
+	if key == key2 {
+		t.Error("GenerateVaultEncKey() should generate different keys each time")
+	}
+}
+
+func TestGenerateRealtimeDBEncKey(t *testing.T) {
+	key, err := GenerateRealtimeDBEncKey()
+	if err != nil {
+		t.Fatalf("GenerateRealtimeDBEncKey() error = %v", err)
+	}
+
+	if len(key) != 16 {
+		t.Errorf("GenerateRealtimeDBEncKey() length = %d, want 16", len(key))
+	}
+}
+
+func TestGenerateAccessToken(t *testing.T) {
+	token, err := GenerateAccessToken()
+	if err != nil {
+		t.Fatalf("GenerateAccessToken() error = %v", err)
+	}
+
+	if len(token) != 64 {
+		t.Errorf("GenerateAccessToken() length = %d, want 64", len(token))
+	}
+
+	if strings.ContainsAny(token, "+/=") {
+		t.Errorf("GenerateAccessToken() = %q, want URL-safe characters only", token)
+	}
+}
+
 func TestGenerateAnonKey(t *testing.T) {
 	jwtSecret := "dGVzdC1zZWNyZXQtdGhhdC1pcy1sb25nLWVub3VnaAAAAAAAAAAAAAAAAAAAAA=="
 
@@ -198,3 +258,189 @@ func TestGenerateServiceRoleKey_EmptySecret(t *testing.T) {
 		t.Error("GenerateServiceRoleKey() with empty secret should return error")
 	}
 }
+
+func TestGenerateAPIKey_TTL(t *testing.T) {
+	secret, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+
+	before := time.Now().Truncate(time.Second)
+	key, err := GenerateAPIKey(secret, "anon", 365*24*time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+	if err := VerifyAPIKey(key, secret, "anon"); err != nil {
+		t.Errorf("VerifyAPIKey() error = %v", err)
+	}
+
+	expiry, err := APIKeyExpiry(key)
+	if err != nil {
+		t.Fatalf("APIKeyExpiry() error = %v", err)
+	}
+	want := before.Add(365 * 24 * time.Hour)
+	if expiry.Before(want) || expiry.After(want.Add(2*time.Second)) {
+		t.Errorf("APIKeyExpiry() = %v, want about %v", expiry, want)
+	}
+
+	noExp, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"role": "anon"}).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	if expiry, err := APIKeyExpiry(noExp); err != nil || !expiry.IsZero() {
+		t.Errorf("APIKeyExpiry() without exp = %v, %v, want zero time", expiry, err)
+	}
+
+	if _, err := APIKeyExpiry("not-a-token"); err == nil {
+		t.Error("APIKeyExpiry() with a malformed token should return error")
+	}
+}
+
+func TestValidateJWTSecret(t *testing.T) {
+	secret, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+
+	if err := ValidateJWTSecret(secret); err != nil {
+		t.Errorf("ValidateJWTSecret() error = %v", err)
+	}
+
+	if err := ValidateJWTSecret(""); err == nil {
+		t.Error("ValidateJWTSecret() with empty secret should return error")
+	}
+
+	if err := ValidateJWTSecret("invalid-base64!"); err == nil {
+		t.Error("ValidateJWTSecret() with invalid secret should return error")
+	}
+}
+
+func TestVerifyAPIKey(t *testing.T) {
+	secret, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+
+	otherSecret, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+
+	anonKey, err := GenerateAnonKey(secret)
+	if err != nil {
+		t.Fatalf("GenerateAnonKey() error = %v", err)
+	}
+
+	if err := VerifyAPIKey(anonKey, secret, "anon"); err != nil {
+		t.Errorf("VerifyAPIKey() error = %v", err)
+	}
+
+	if err := VerifyAPIKey(anonKey, otherSecret, "anon"); err == nil {
+		t.Error("VerifyAPIKey() with a different secret should return error")
+	}
+
+	if err := VerifyAPIKey(anonKey, secret, "service_role"); err == nil {
+		t.Error("VerifyAPIKey() with the wrong role should return error")
+	}
+
+	if err := VerifyAPIKey("not-a-token", secret, "anon"); err == nil {
+		t.Error("VerifyAPIKey() with a malformed token should return error")
+	}
+
+	decoded, _ := base64.StdEncoding.DecodeString(secret)
+	hs512, err := jwt.NewWithClaims(jwt.SigningMethodHS512, jwt.MapClaims{"role": "anon"}).SignedString(decoded)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	if err := VerifyAPIKey(hs512, secret, "anon"); err == nil {
+		t.Error("VerifyAPIKey() should only accept HS256 tokens")
+	}
+}
+
+func TestSignNamedAPIKey(t *testing.T) {
+	secret, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	key, keyID, err := SignNamedAPIKey(secret, "reporting", map[string]string{"team": "billing"}, expiresAt)
+	if err != nil {
+		t.Fatalf("SignNamedAPIKey() error = %v", err)
+	}
+	if err := VerifyAPIKey(key, secret, "reporting"); err != nil {
+		t.Errorf("VerifyAPIKey() error = %v", err)
+	}
+
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(key, claims); err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+	if claims["team"] != "billing" || claims["jti"] != keyID || keyID == "" {
+		t.Errorf("SignNamedAPIKey() claims = %v, want team and jti %q", claims, keyID)
+	}
+	if id, _ := APIKeyID(key); id != keyID {
+		t.Errorf("APIKeyID() = %q, want %q", id, keyID)
+	}
+	if expiry, _ := APIKeyExpiry(key); !expiry.Equal(expiresAt) {
+		t.Errorf("APIKeyExpiry() = %v, want %v", expiry, expiresAt)
+	}
+
+	forever, _, err := SignNamedAPIKey(secret, "reporting", nil, time.Time{})
+	if err != nil {
+		t.Fatalf("SignNamedAPIKey() error = %v", err)
+	}
+	if expiry, _ := APIKeyExpiry(forever); !expiry.IsZero() {
+		t.Errorf("SignNamedAPIKey() without expiry set exp to %v", expiry)
+	}
+
+	if _, _, err := SignNamedAPIKey(secret, "reporting", map[string]string{"role": "service_role"}, time.Time{}); err == nil {
+		t.Error("SignNamedAPIKey() should not let claims override the role")
+	}
+}
+
+func TestBuildJWKS(t *testing.T) {
+	current, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+	previous, err := GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+
+	jwks, err := BuildJWKS(current, previous)
+	if err != nil {
+		t.Fatalf("BuildJWKS() error = %v", err)
+	}
+
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Alg string `json:"alg"`
+			K   string `json:"k"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal([]byte(jwks), &set); err != nil {
+		t.Fatalf("BuildJWKS() returned invalid JSON: %v", err)
+	}
+
+	if len(set.Keys) != 2 {
+		t.Fatalf("BuildJWKS() returned %d keys, want 2", len(set.Keys))
+	}
+
+	for i, secret := range []string{current, previous} {
+		key := set.Keys[i]
+		if key.Kty != "oct" || key.Alg != "HS256" {
+			t.Errorf("key %d = %s/%s, want oct/HS256", i, key.Kty, key.Alg)
+		}
+		decoded, _ := base64.StdEncoding.DecodeString(secret)
+		if key.K != base64.RawURLEncoding.EncodeToString(decoded) {
+			t.Errorf("key %d does not hold the decoded secret", i)
+		}
+	}
+
+	if _, err := BuildJWKS("invalid-base64!"); err == nil {
+		t.Error("BuildJWKS() with invalid secret should return error")
+	}
+}
@@ -0,0 +1,21 @@
+package secrets
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+)
+
+const samlKeyBits = 2048
+
+// GenerateSAMLPrivateKey returns a new RSA private key in the format GoTrue
+// expects for GOTRUE_SAML_PRIVATE_KEY: base64-encoded PKCS#1 DER.
+func GenerateSAMLPrivateKey() (string, error) {
+	key, err := rsa.GenerateKey(rand.Reader, samlKeyBits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate RSA key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(x509.MarshalPKCS1PrivateKey(key)), nil
+}
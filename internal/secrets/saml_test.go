@@ -0,0 +1,36 @@
+package secrets
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+)
+
+func TestGenerateSAMLPrivateKey(t *testing.T) {
+	encoded, err := GenerateSAMLPrivateKey()
+	if err != nil {
+		t.Fatalf("GenerateSAMLPrivateKey() error = %v", err)
+	}
+
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("GenerateSAMLPrivateKey() returned invalid base64: %v", err)
+	}
+
+	key, err := x509.ParsePKCS1PrivateKey(der)
+	if err != nil {
+		t.Fatalf("GenerateSAMLPrivateKey() returned invalid PKCS#1 key: %v", err)
+	}
+
+	if key.N.BitLen() != 2048 {
+		t.Errorf("GenerateSAMLPrivateKey() key size = %d, want 2048", key.N.BitLen())
+	}
+
+	encoded2, err := GenerateSAMLPrivateKey()
+	if err != nil {
+		t.Fatalf("GenerateSAMLPrivateKey() second call error = %v", err)
+	}
+	if encoded == encoded2 {
+		t.Error("GenerateSAMLPrivateKey() returned the same key twice")
+	}
+}
@@ -41,3 +41,37 @@ func ValidateBasicAuthSecret(secret *corev1.Secret) error {
 
 	return nil
 }
+
+func ValidateSMTPSecret(secret *corev1.Secret) error {
+	requiredKeys := []string{"host", "port", "username", "password", "from"}
+
+	for _, key := range requiredKeys {
+		if _, ok := secret.Data[key]; !ok {
+			return fmt.Errorf("missing required key '%s'", key)
+		}
+	}
+
+	return nil
+}
+
+var smsProviderSecretKeys = map[string][]string{
+	"twilio":      {"accountSid", "authToken", "messageServiceSid"},
+	"messagebird": {"accessKey", "originator"},
+	"textlocal":   {"apiKey", "sender"},
+	"vonage":      {"apiKey", "apiSecret", "from"},
+}
+
+func ValidateSMSSecret(provider string, secret *corev1.Secret) error {
+	requiredKeys, ok := smsProviderSecretKeys[provider]
+	if !ok {
+		return fmt.Errorf("unsupported sms provider '%s'", provider)
+	}
+
+	for _, key := range requiredKeys {
+		if _, ok := secret.Data[key]; !ok {
+			return fmt.Errorf("missing required key '%s'", key)
+		}
+	}
+
+	return nil
+}
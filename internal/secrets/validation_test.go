@@ -351,3 +351,120 @@ func TestValidateBasicAuthSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateSMTPSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  *corev1.Secret
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid smtp secret",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "smtp-config"},
+				Data: map[string][]byte{
+					"host":     []byte("smtp.example.com"),
+					"port":     []byte("587"),
+					"username": []byte("mailer"),
+					"password": []byte("password"),
+					"from":     []byte("noreply@example.com"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing from",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "smtp-config"},
+				Data: map[string][]byte{
+					"host":     []byte("smtp.example.com"),
+					"port":     []byte("587"),
+					"username": []byte("mailer"),
+					"password": []byte("password"),
+				},
+			},
+			wantErr: true,
+			errMsg:  "missing required key 'from'",
+		},
+		{
+			name: "missing host",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "smtp-config"},
+				Data:       map[string][]byte{},
+			},
+			wantErr: true,
+			errMsg:  "missing required key 'host'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSMTPSecret(tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSMTPSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && err != nil && err.Error() != tt.errMsg {
+				t.Fatalf("ValidateSMTPSecret() error = %v, want %v", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateSMSSecret(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		secret   *corev1.Secret
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:     "valid twilio secret",
+			provider: "twilio",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "sms"},
+				Data: map[string][]byte{
+					"accountSid":        []byte("AC123"),
+					"authToken":         []byte("token"),
+					"messageServiceSid": []byte("MG123"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "vonage secret missing apiSecret",
+			provider: "vonage",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "sms"},
+				Data: map[string][]byte{
+					"apiKey": []byte("key"),
+					"from":   []byte("Supabase"),
+				},
+			},
+			wantErr: true,
+			errMsg:  "missing required key 'apiSecret'",
+		},
+		{
+			name:     "unsupported provider",
+			provider: "carrier-pigeon",
+			secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "sms"},
+			},
+			wantErr: true,
+			errMsg:  "unsupported sms provider 'carrier-pigeon'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSMSSecret(tt.provider, tt.secret)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateSMSSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && err != nil && err.Error() != tt.errMsg {
+				t.Fatalf("ValidateSMSSecret() error = %v, want %v", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
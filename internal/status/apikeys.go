@@ -0,0 +1,43 @@
+package status
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/component"
+	"github.com/strrl/supabase-operator/internal/secrets"
+)
+
+// NewAPIKeysStatus reports the API keys held by the <project>-jwt Secret. The
+// publishable and anon keys ship in client code anyway, so they are copied;
+// the secret and service role keys are only referenced. The expiry of the JWT
+// keys is read from their exp claim.
+func NewAPIKeysStatus(secret *corev1.Secret) v1alpha1.APIKeysStatus {
+	ref := func(key string) *corev1.SecretKeySelector {
+		if len(secret.Data[key]) == 0 {
+			return nil
+		}
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+			Key:                  key,
+		}
+	}
+
+	expiresAt := func(key string) *metav1.Time {
+		expiry, err := secrets.APIKeyExpiry(string(secret.Data[key]))
+		if err != nil || expiry.IsZero() {
+			return nil
+		}
+		return &metav1.Time{Time: expiry}
+	}
+
+	return v1alpha1.APIKeysStatus{
+		PublishableKey:          string(secret.Data[component.PublishableKeyKey]),
+		AnonKey:                 string(secret.Data["anon-key"]),
+		AnonKeyExpiresAt:        expiresAt("anon-key"),
+		SecretKeyRef:            ref(component.SecretKeyKey),
+		ServiceRoleKeyRef:       ref("service-role-key"),
+		ServiceRoleKeyExpiresAt: expiresAt("service-role-key"),
+	}
+}
@@ -0,0 +1,64 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/strrl/supabase-operator/internal/secrets"
+)
+
+func TestNewAPIKeysStatus(t *testing.T) {
+	jwtSecret, err := secrets.GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+	serviceRoleKey, err := secrets.GenerateAPIKey(jwtSecret, "service_role", time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateAPIKey() error = %v", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-supabase-jwt"},
+		Data: map[string][]byte{
+			"jwt-secret":       []byte(jwtSecret),
+			"anon-key":         []byte("eyJ.anon"),
+			"service-role-key": []byte(serviceRoleKey),
+			"publishable-key":  []byte("sb_publishable_abc"),
+			"secret-key":       []byte("sb_secret_xyz"),
+		},
+	}
+
+	apiKeys := NewAPIKeysStatus(secret)
+
+	if apiKeys.PublishableKey != "sb_publishable_abc" {
+		t.Errorf("Expected PublishableKey 'sb_publishable_abc', got '%s'", apiKeys.PublishableKey)
+	}
+
+	if apiKeys.AnonKey != "eyJ.anon" {
+		t.Errorf("Expected AnonKey 'eyJ.anon', got '%s'", apiKeys.AnonKey)
+	}
+
+	if ref := apiKeys.SecretKeyRef; ref == nil || ref.Name != "my-supabase-jwt" || ref.Key != "secret-key" {
+		t.Errorf("Expected SecretKeyRef my-supabase-jwt/secret-key, got %+v", ref)
+	}
+
+	if ref := apiKeys.ServiceRoleKeyRef; ref == nil || ref.Name != "my-supabase-jwt" || ref.Key != "service-role-key" {
+		t.Errorf("Expected ServiceRoleKeyRef my-supabase-jwt/service-role-key, got %+v", ref)
+	}
+
+	if expiresAt := apiKeys.ServiceRoleKeyExpiresAt; expiresAt == nil || time.Until(expiresAt.Time) > time.Hour {
+		t.Errorf("Expected ServiceRoleKeyExpiresAt within an hour, got %v", expiresAt)
+	}
+
+	if apiKeys.AnonKeyExpiresAt != nil {
+		t.Errorf("Expected no AnonKeyExpiresAt for a malformed key, got %v", apiKeys.AnonKeyExpiresAt)
+	}
+
+	delete(secret.Data, "secret-key")
+	if ref := NewAPIKeysStatus(secret).SecretKeyRef; ref != nil {
+		t.Errorf("Expected no SecretKeyRef without a secret key, got %+v", ref)
+	}
+}
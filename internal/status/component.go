@@ -30,6 +30,16 @@ func SetComponentStatus(componentsStatus v1alpha1.ComponentsStatus, component st
 		componentsStatus.PostgREST = status
 	case "StorageAPI":
 		componentsStatus.StorageAPI = status
+	case "Imgproxy":
+		componentsStatus.Imgproxy = &status
+	case "Functions":
+		componentsStatus.Functions = &status
+	case "Pooler":
+		componentsStatus.Pooler = &status
+	case "Analytics":
+		componentsStatus.Analytics = &status
+	case "Vector":
+		componentsStatus.Vector = &status
 	case "Meta":
 		componentsStatus.Meta = status
 	case "Studio":
@@ -48,6 +58,17 @@ func AreAllComponentsReady(componentsStatus v1alpha1.ComponentsStatus) bool {
 		componentsStatus.Meta,
 		componentsStatus.Studio,
 	}
+	for _, optional := range []*v1alpha1.ComponentStatus{
+		componentsStatus.Imgproxy,
+		componentsStatus.Functions,
+		componentsStatus.Pooler,
+		componentsStatus.Analytics,
+		componentsStatus.Vector,
+	} {
+		if optional != nil {
+			components = append(components, *optional)
+		}
+	}
 
 	for _, comp := range components {
 		if !comp.Ready {
@@ -75,6 +96,31 @@ func GetComponentByName(componentsStatus v1alpha1.ComponentsStatus, name string)
 		return componentsStatus.PostgREST
 	case "StorageAPI":
 		return componentsStatus.StorageAPI
+	case "Imgproxy":
+		if componentsStatus.Imgproxy == nil {
+			return v1alpha1.ComponentStatus{}
+		}
+		return *componentsStatus.Imgproxy
+	case "Functions":
+		if componentsStatus.Functions == nil {
+			return v1alpha1.ComponentStatus{}
+		}
+		return *componentsStatus.Functions
+	case "Pooler":
+		if componentsStatus.Pooler == nil {
+			return v1alpha1.ComponentStatus{}
+		}
+		return *componentsStatus.Pooler
+	case "Analytics":
+		if componentsStatus.Analytics == nil {
+			return v1alpha1.ComponentStatus{}
+		}
+		return *componentsStatus.Analytics
+	case "Vector":
+		if componentsStatus.Vector == nil {
+			return v1alpha1.ComponentStatus{}
+		}
+		return *componentsStatus.Vector
 	case "Meta":
 		return componentsStatus.Meta
 	case "Studio":
@@ -166,6 +166,22 @@ func TestAreAllComponentsReady(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "enabled imgproxy not ready",
+			setup: func() v1alpha1.ComponentsStatus {
+				cs := v1alpha1.ComponentsStatus{}
+				cs = SetComponentStatus(cs, "Kong", NewComponentStatus(PhaseRunning, "kong:2.8.1", 1, 1))
+				cs = SetComponentStatus(cs, "Auth", NewComponentStatus(PhaseRunning, "supabase/gotrue:v2.177.0", 1, 1))
+				cs = SetComponentStatus(cs, "Realtime", NewComponentStatus(PhaseRunning, "supabase/realtime:v2.34.47", 1, 1))
+				cs = SetComponentStatus(cs, "PostgREST", NewComponentStatus(PhaseRunning, "postgrest/postgrest:v12.2.12", 1, 1))
+				cs = SetComponentStatus(cs, "StorageAPI", NewComponentStatus(PhaseRunning, "supabase/storage-api:v1.25.7", 1, 1))
+				cs = SetComponentStatus(cs, "Meta", NewComponentStatus(PhaseRunning, "supabase/postgres-meta:v0.91.0", 1, 1))
+				cs = SetComponentStatus(cs, "Studio", NewComponentStatus(PhaseRunning, "supabase/studio:2025.10.01-sha-8460121", 1, 1))
+				cs = SetComponentStatus(cs, "Imgproxy", NewComponentStatus(PhaseDeployingComponents, "darthsim/imgproxy:v3.8.0", 1, 0))
+				return cs
+			},
+			expected: false,
+		},
 		{
 			name: "one component not ready",
 			setup: func() v1alpha1.ComponentsStatus {
@@ -22,6 +22,10 @@ const (
 	ConditionTypeS3Connected         = "S3Connected"
 	ConditionTypeSecretsReady        = "SecretsReady"
 	ConditionTypeNetworkReady        = "NetworkReady"
+	ConditionTypeSMTPConfigured      = "SMTPConfigured"
+	ConditionTypeSMSConfigured       = "SMSConfigured"
+	ConditionTypeAuthHooksReady      = "AuthHooksReady"
+	ConditionTypeJWTRotation         = "JWTRotation"
 )
 
 func NewReadyCondition(status metav1.ConditionStatus, reason, message string) metav1.Condition {
@@ -0,0 +1,30 @@
+package status
+
+import (
+	"strings"
+
+	"github.com/strrl/supabase-operator/api/v1alpha1"
+)
+
+// NewEndpointsStatus derives the per-service endpoints from the base URL that
+// fronts Kong. Realtime is exposed over websockets, so its scheme is switched
+// to ws/wss.
+func NewEndpointsStatus(baseURL string) v1alpha1.EndpointsStatus {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+
+	realtimeURL := baseURL
+	switch {
+	case strings.HasPrefix(realtimeURL, "https://"):
+		realtimeURL = "wss://" + strings.TrimPrefix(realtimeURL, "https://")
+	case strings.HasPrefix(realtimeURL, "http://"):
+		realtimeURL = "ws://" + strings.TrimPrefix(realtimeURL, "http://")
+	}
+
+	return v1alpha1.EndpointsStatus{
+		API:      baseURL,
+		Auth:     baseURL + "/auth/v1",
+		Realtime: realtimeURL + "/realtime/v1",
+		Storage:  baseURL + "/storage/v1",
+		REST:     baseURL + "/rest/v1",
+	}
+}
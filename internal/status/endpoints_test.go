@@ -0,0 +1,37 @@
+package status
+
+import (
+	"testing"
+)
+
+func TestNewEndpointsStatus(t *testing.T) {
+	endpoints := NewEndpointsStatus("https://supabase.example.com/")
+
+	if endpoints.API != "https://supabase.example.com" {
+		t.Errorf("Expected API 'https://supabase.example.com', got '%s'", endpoints.API)
+	}
+
+	if endpoints.Auth != "https://supabase.example.com/auth/v1" {
+		t.Errorf("Expected Auth 'https://supabase.example.com/auth/v1', got '%s'", endpoints.Auth)
+	}
+
+	if endpoints.REST != "https://supabase.example.com/rest/v1" {
+		t.Errorf("Expected REST 'https://supabase.example.com/rest/v1', got '%s'", endpoints.REST)
+	}
+
+	if endpoints.Storage != "https://supabase.example.com/storage/v1" {
+		t.Errorf("Expected Storage 'https://supabase.example.com/storage/v1', got '%s'", endpoints.Storage)
+	}
+
+	if endpoints.Realtime != "wss://supabase.example.com/realtime/v1" {
+		t.Errorf("Expected Realtime 'wss://supabase.example.com/realtime/v1', got '%s'", endpoints.Realtime)
+	}
+}
+
+func TestNewEndpointsStatus_InCluster(t *testing.T) {
+	endpoints := NewEndpointsStatus("http://demo-kong.default.svc:8000")
+
+	if endpoints.Realtime != "ws://demo-kong.default.svc:8000/realtime/v1" {
+		t.Errorf("Expected Realtime 'ws://demo-kong.default.svc:8000/realtime/v1', got '%s'", endpoints.Realtime)
+	}
+}
@@ -3,6 +3,8 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -14,6 +16,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/component"
+	"github.com/strrl/supabase-operator/internal/database"
+	"github.com/strrl/supabase-operator/internal/secrets"
 )
 
 // +kubebuilder:object:generate=false
@@ -36,6 +41,17 @@ var _ webhook.CustomDefaulter = &SupabaseProjectWebhook{}
 var (
 	requiredDatabaseSecretKeys = []string{"host", "port", "database", "username", "password"}
 	requiredStorageSecretKeys  = []string{"endpoint", "region", "bucket", "accessKeyId", "secretAccessKey"}
+	requiredSMTPSecretKeys     = []string{"host", "port", "username", "password", "from"}
+
+	requiredS3ProtocolSecretKeys = []string{"accessKeyId", "secretAccessKey"}
+
+	// supportedOAuthProviders mirrors the external providers GoTrue understands.
+	supportedOAuthProviders = map[string]bool{
+		"apple": true, "azure": true, "bitbucket": true, "discord": true, "facebook": true,
+		"figma": true, "github": true, "gitlab": true, "google": true, "kakao": true,
+		"keycloak": true, "linkedin_oidc": true, "notion": true, "slack_oidc": true,
+		"spotify": true, "twitch": true, "twitter": true, "workos": true, "zoom": true,
+	}
 )
 
 func (r *SupabaseProjectWebhook) Default(ctx context.Context, obj runtime.Object) error {
@@ -79,6 +95,26 @@ func (r *SupabaseProjectWebhook) Default(ctx context.Context, obj runtime.Object
 		project.Spec.StorageAPI.Image = supabasev1alpha1.DefaultStorageAPIImage
 	}
 
+	if project.Spec.Imgproxy != nil && project.Spec.Imgproxy.Image == "" {
+		project.Spec.Imgproxy.Image = supabasev1alpha1.DefaultImgproxyImage
+	}
+
+	if project.Spec.Functions != nil && project.Spec.Functions.Image == "" {
+		project.Spec.Functions.Image = supabasev1alpha1.DefaultFunctionsImage
+	}
+
+	if project.Spec.Pooler != nil && project.Spec.Pooler.Image == "" {
+		project.Spec.Pooler.Image = supabasev1alpha1.DefaultPoolerImage
+	}
+
+	if project.Spec.Analytics != nil && project.Spec.Analytics.Image == "" {
+		project.Spec.Analytics.Image = supabasev1alpha1.DefaultAnalyticsImage
+	}
+
+	if project.Spec.Vector != nil && project.Spec.Vector.Image == "" {
+		project.Spec.Vector.Image = supabasev1alpha1.DefaultVectorImage
+	}
+
 	if project.Spec.Meta == nil {
 		project.Spec.Meta = &supabasev1alpha1.MetaConfig{}
 	}
@@ -112,11 +148,77 @@ func (r *SupabaseProjectWebhook) ValidateCreate(ctx context.Context, obj runtime
 		return nil, err
 	}
 
+	// Validate the user-supplied JWT secret
+	if err := r.validateJWT(ctx, project); err != nil {
+		return nil, err
+	}
+
+	// Validate Auth settings
+	if err := r.validateAuthSettings(project); err != nil {
+		return nil, err
+	}
+
+	// Validate OAuth providers and their client secrets
+	if err := r.validateOAuthProviders(ctx, project); err != nil {
+		return nil, err
+	}
+
+	// Validate auth hooks
+	if err := r.validateAuthHooks(ctx, project); err != nil {
+		return nil, err
+	}
+
+	// Validate SAML configuration
+	if err := r.validateSAML(ctx, project); err != nil {
+		return nil, err
+	}
+
+	// Validate the storage backend
+	if err := r.validateStorage(project); err != nil {
+		return nil, err
+	}
+
+	// Validate PostgREST configuration
+	if err := r.validatePostgREST(project); err != nil {
+		return nil, err
+	}
+
+	// Validate connection pooler settings
+	if err := r.validatePooler(project); err != nil {
+		return nil, err
+	}
+
+	// Validate edge functions sources
+	if err := r.validateFunctions(ctx, project); err != nil {
+		return nil, err
+	}
+
+	// Validate the log pipeline
+	if err := r.validateAnalytics(project); err != nil {
+		return nil, err
+	}
+
 	// Validate image references
 	if err := r.validateImages(project); err != nil {
 		return nil, err
 	}
 
+	// Validate ingress configuration
+	if err := r.validateIngress(project); err != nil {
+		return nil, err
+	}
+
+	// Validate gateway configuration
+	if err := r.validateGateway(project); err != nil {
+		return nil, err
+	}
+
+	// Validate Kong overrides, which must not replace the built-in routes or
+	// the pre-function slot the API key revocation uses
+	if err := r.validateKong(project); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -135,8 +237,8 @@ func (r *SupabaseProjectWebhook) validateSecretReferences(project *supabasev1alp
 		return fmt.Errorf("database.secretRef.name cannot be empty")
 	}
 
-	// Validate storage secret reference
-	if project.Spec.Storage.SecretRef.Name == "" {
+	// Validate storage secret reference, the file backend does not use one
+	if project.Spec.Storage.Backend != "file" && project.Spec.Storage.SecretRef.Name == "" {
 		return fmt.Errorf("storage.secretRef.name cannot be empty")
 	}
 
@@ -153,19 +255,128 @@ func (r *SupabaseProjectWebhook) validateSecrets(ctx context.Context, project *s
 		return err
 	}
 
-	storageSecret, err := r.getSecret(ctx, project, project.Spec.Storage.SecretRef, "storage")
-	if err != nil {
-		return err
+	// The file backend does not use a storage secret
+	var storageSecret *corev1.Secret
+	if project.Spec.Storage.Backend != "file" {
+		storageSecret, err = r.getSecret(ctx, project, project.Spec.Storage.SecretRef, "storage")
+		if err != nil {
+			return err
+		}
 	}
 
 	if err := ensureSecretKeys(dbSecret, requiredDatabaseSecretKeys, "database"); err != nil {
 		return err
 	}
 
-	if err := ensureSecretKeys(storageSecret, requiredStorageSecretKeys, "storage"); err != nil {
+	if storageSecret != nil {
+		if err := ensureSecretKeys(storageSecret, requiredStorageSecretKeys, "storage"); err != nil {
+			return err
+		}
+	}
+
+	if project.Spec.StorageAPI != nil && project.Spec.StorageAPI.S3ProtocolSecretRef != nil {
+		s3ProtocolSecret, err := r.getSecret(ctx, project, *project.Spec.StorageAPI.S3ProtocolSecretRef, "storage s3 protocol")
+		if err != nil {
+			return err
+		}
+		if err := ensureSecretKeys(s3ProtocolSecret, requiredS3ProtocolSecretKeys, "storage s3 protocol"); err != nil {
+			return err
+		}
+	}
+
+	if project.Spec.Auth != nil && project.Spec.Auth.SMTPSecretRef != nil {
+		smtpSecret, err := r.getSecret(ctx, project, *project.Spec.Auth.SMTPSecretRef, "smtp")
+		if err != nil {
+			return err
+		}
+		if err := ensureSecretKeys(smtpSecret, requiredSMTPSecretKeys, "smtp"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateJWT checks that the Secret referenced by jwt.secretRef holds a usable
+// JWT secret, that any JWT API keys it supplies were signed by that secret and
+// that any opaque API keys carry the right prefix. Only the generated secret
+// can be rotated by the operator, and API keys must live longer than their
+// renewal threshold.
+func (r *SupabaseProjectWebhook) validateJWT(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.JWT == nil {
+		return nil
+	}
+
+	if err := validateAPIKeyLifetime(project.Spec.JWT); err != nil {
 		return err
 	}
 
+	if project.Spec.JWT.SecretRef == nil {
+		return nil
+	}
+
+	if project.Spec.JWT.Rotation != nil {
+		return fmt.Errorf("jwt.rotation cannot be combined with jwt.secretRef, rotate the referenced secret instead")
+	}
+
+	ref := *project.Spec.JWT.SecretRef
+	if ref.Name == "" {
+		return fmt.Errorf("jwt.secretRef.name cannot be empty")
+	}
+
+	secret, err := r.getSecret(ctx, project, ref, "jwt")
+	if err != nil {
+		return err
+	}
+
+	if err := ensureSecretKeys(secret, []string{"jwt-secret"}, "jwt"); err != nil {
+		return err
+	}
+
+	jwtSecret := string(secret.Data["jwt-secret"])
+	if err := secrets.ValidateJWTSecret(jwtSecret); err != nil {
+		return fmt.Errorf("jwt secret key 'jwt-secret' is invalid: %w", err)
+	}
+
+	for _, apiKey := range []struct{ key, role string }{
+		{"anon-key", "anon"},
+		{"service-role-key", "service_role"},
+	} {
+		value, ok := secret.Data[apiKey.key]
+		if !ok || len(value) == 0 {
+			continue
+		}
+		if err := secrets.VerifyAPIKey(string(value), jwtSecret, apiKey.role); err != nil {
+			return fmt.Errorf("jwt secret key '%s' is not signed by 'jwt-secret': %w", apiKey.key, err)
+		}
+	}
+
+	for _, opaqueKey := range []struct{ key, prefix string }{
+		{"publishable-key", secrets.PublishableKeyPrefix},
+		{"secret-key", secrets.SecretKeyPrefix},
+	} {
+		value, ok := secret.Data[opaqueKey.key]
+		if !ok || len(value) == 0 {
+			continue
+		}
+		if err := secrets.ValidateOpaqueAPIKey(string(value), opaqueKey.prefix); err != nil {
+			return fmt.Errorf("jwt secret key '%s' is invalid: %w", opaqueKey.key, err)
+		}
+	}
+
+	return nil
+}
+
+// validateAPIKeyLifetime makes sure API keys are not re-minted as soon as they
+// are issued.
+func validateAPIKeyLifetime(config *supabasev1alpha1.JWTConfig) error {
+	ttl := int64(secrets.DefaultAPIKeyTTL.Seconds())
+	if config.APIKeyTTL != nil {
+		ttl = int64(*config.APIKeyTTL)
+	}
+	if config.APIKeyRenewBeforeSeconds != nil && int64(*config.APIKeyRenewBeforeSeconds) >= ttl {
+		return fmt.Errorf("jwt.apiKeyRenewBeforeSeconds (%d) must be less than jwt.apiKeyTTL (%d)", *config.APIKeyRenewBeforeSeconds, ttl)
+	}
 	return nil
 }
 
@@ -201,6 +412,270 @@ func ensureSecretKeys(secret *corev1.Secret, keys []string, secretType string) e
 	return nil
 }
 
+func (r *SupabaseProjectWebhook) validateAuthSettings(project *supabasev1alpha1.SupabaseProject) error {
+	auth := project.Spec.Auth
+	if auth == nil {
+		return nil
+	}
+
+	if auth.SiteURL != "" {
+		if u, err := url.Parse(auth.SiteURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("auth.siteURL must be an absolute URL")
+		}
+	}
+	for i, redirectURL := range auth.AdditionalRedirectURLs {
+		if strings.TrimSpace(redirectURL) == "" || strings.Contains(redirectURL, ",") {
+			return fmt.Errorf("auth.additionalRedirectURLs[%d] must be a non-empty URL without commas", i)
+		}
+	}
+
+	if auth.JWTExpirySeconds != 0 && (auth.JWTExpirySeconds < 30 || auth.JWTExpirySeconds > 604800) {
+		return fmt.Errorf("auth.jwtExpirySeconds must be between 30 and 604800")
+	}
+	if auth.PasswordMinLength != 0 && (auth.PasswordMinLength < 6 || auth.PasswordMinLength > 72) {
+		return fmt.Errorf("auth.passwordMinLength must be between 6 and 72")
+	}
+
+	if limits := auth.RateLimits; limits != nil {
+		for _, limit := range []struct {
+			name  string
+			value int32
+		}{
+			{"emailSent", limits.EmailSent},
+			{"smsSent", limits.SMSSent},
+			{"verify", limits.Verify},
+			{"tokenRefresh", limits.TokenRefresh},
+			{"otp", limits.OTP},
+			{"anonymousUsers", limits.AnonymousUsers},
+		} {
+			if limit.value < 0 {
+				return fmt.Errorf("auth.rateLimits.%s must not be negative", limit.name)
+			}
+		}
+	}
+
+	if mfa := auth.MFA; mfa != nil && mfa.MaxEnrolledFactors != 0 &&
+		(mfa.MaxEnrolledFactors < 1 || mfa.MaxEnrolledFactors > 100) {
+		return fmt.Errorf("auth.mfa.maxEnrolledFactors must be between 1 and 100")
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validateOAuthProviders(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Auth == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	for i, provider := range project.Spec.Auth.Providers {
+		if !supportedOAuthProviders[provider.Name] {
+			return fmt.Errorf("auth.providers[%d].name '%s' is not a supported OAuth provider", i, provider.Name)
+		}
+		if seen[provider.Name] {
+			return fmt.Errorf("auth.providers[%d].name '%s' is configured more than once", i, provider.Name)
+		}
+		seen[provider.Name] = true
+
+		if provider.ClientID == "" {
+			return fmt.Errorf("auth.providers[%d].clientId cannot be empty", i)
+		}
+		if provider.SecretRef.Name == "" || provider.SecretRef.Key == "" {
+			return fmt.Errorf("auth.providers[%d].secretRef requires name and key", i)
+		}
+		if provider.Name == "keycloak" && provider.URL == "" {
+			return fmt.Errorf("auth.providers[%d].url is required for keycloak", i)
+		}
+
+		secretType := fmt.Sprintf("oauth provider '%s'", provider.Name)
+		secret, err := r.getSecret(ctx, project, corev1.SecretReference{Name: provider.SecretRef.Name}, secretType)
+		if err != nil {
+			return err
+		}
+		if err := ensureSecretKeys(secret, []string{provider.SecretRef.Key}, secretType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validateAuthHooks(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Auth == nil {
+		return nil
+	}
+
+	for i, hook := range project.Spec.Auth.Hooks {
+		if strings.HasPrefix(hook.URI, database.PGFunctionsScheme+"://") {
+			if _, _, err := database.ParsePGFunctionURI(hook.URI); err != nil {
+				return fmt.Errorf("auth.hooks[%d].uri: %w", i, err)
+			}
+			continue
+		}
+
+		u, err := url.Parse(hook.URI)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("auth.hooks[%d].uri must be a pg-functions:// URI or an http(s) URL", i)
+		}
+		if hook.SecretRef == nil || hook.SecretRef.Name == "" || hook.SecretRef.Key == "" {
+			return fmt.Errorf("auth.hooks[%d].secretRef is required for HTTP hooks", i)
+		}
+
+		secretType := fmt.Sprintf("%s hook", hook.Type)
+		secret, err := r.getSecret(ctx, project, corev1.SecretReference{Name: hook.SecretRef.Name}, secretType)
+		if err != nil {
+			return err
+		}
+		if err := ensureSecretKeys(secret, []string{hook.SecretRef.Key}, secretType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validateSAML(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Auth == nil || project.Spec.Auth.SAML == nil || !project.Spec.Auth.SAML.Enabled {
+		return nil
+	}
+
+	ref := project.Spec.Auth.SAML.PrivateKeySecretRef
+	if ref == nil {
+		return nil
+	}
+	if ref.Name == "" || ref.Key == "" {
+		return fmt.Errorf("auth.saml.privateKeySecretRef requires name and key")
+	}
+
+	secret, err := r.getSecret(ctx, project, corev1.SecretReference{Name: ref.Name}, "saml")
+	if err != nil {
+		return err
+	}
+	return ensureSecretKeys(secret, []string{ref.Key}, "saml")
+}
+
+func (r *SupabaseProjectWebhook) validatePostgREST(project *supabasev1alpha1.SupabaseProject) error {
+	config := project.Spec.PostgREST
+	if config == nil {
+		return nil
+	}
+
+	for i, schema := range config.ExposedSchemas {
+		if strings.TrimSpace(schema) == "" || strings.Contains(schema, ",") {
+			return fmt.Errorf("postgrest.exposedSchemas[%d] must be a non-empty schema name", i)
+		}
+	}
+	for i, schema := range config.ExtraSearchPath {
+		if strings.TrimSpace(schema) == "" || strings.Contains(schema, ",") {
+			return fmt.Errorf("postgrest.extraSearchPath[%d] must be a non-empty schema name", i)
+		}
+	}
+
+	replicas := config.Replicas
+	if replicas < 1 {
+		replicas = 1
+	}
+	maxConnections := int32(project.Spec.Database.MaxConnections)
+	if config.DBPool > 0 && maxConnections > 0 && config.DBPool*replicas > maxConnections {
+		return fmt.Errorf("postgrest.dbPool (%d) x replicas (%d) exceeds database.maxConnections (%d)",
+			config.DBPool, replicas, maxConnections)
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validateStorage(project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Storage.Backend != "file" {
+		return nil
+	}
+
+	replicas := int32(1)
+	if project.Spec.StorageAPI != nil && project.Spec.StorageAPI.Replicas > 0 {
+		replicas = project.Spec.StorageAPI.Replicas
+	}
+	shared := project.Spec.Storage.File != nil && project.Spec.Storage.File.AccessMode == corev1.ReadWriteMany
+	if replicas > 1 && !shared {
+		return fmt.Errorf("storageApi.replicas (%d) requires storage.file.accessMode ReadWriteMany", replicas)
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validatePooler(project *supabasev1alpha1.SupabaseProject) error {
+	config := project.Spec.Pooler
+	if config == nil {
+		return nil
+	}
+
+	if !config.Enabled {
+		if len(config.RouteComponents) > 0 {
+			return fmt.Errorf("pooler.routeComponents requires pooler.enabled")
+		}
+		return nil
+	}
+
+	maxConnections := int32(project.Spec.Database.MaxConnections)
+	if config.DefaultPoolSize > 0 && maxConnections > 0 && config.DefaultPoolSize > maxConnections {
+		return fmt.Errorf("pooler.defaultPoolSize (%d) exceeds database.maxConnections (%d)",
+			config.DefaultPoolSize, maxConnections)
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validateFunctions(ctx context.Context, project *supabasev1alpha1.SupabaseProject) error {
+	config := project.Spec.Functions
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	sharedSource := false
+	if source := config.Source; source != nil {
+		if (source.PersistentVolumeClaim == nil) == (source.Image == nil) {
+			return fmt.Errorf("functions.source must set exactly one of persistentVolumeClaim or image")
+		}
+		sharedSource = true
+	}
+
+	for i, fn := range config.Functions {
+		if sharedSource {
+			if fn.ConfigMapRef != nil {
+				return fmt.Errorf("functions.functions[%d].configMapRef cannot be combined with functions.source", i)
+			}
+			continue
+		}
+
+		if fn.ConfigMapRef == nil || fn.ConfigMapRef.Name == "" {
+			return fmt.Errorf("functions.functions[%d].configMapRef is required when functions.source is not set", i)
+		}
+
+		var configMap corev1.ConfigMap
+		if err := r.Client.Get(ctx, client.ObjectKey{Namespace: project.Namespace, Name: fn.ConfigMapRef.Name}, &configMap); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("functions configmap '%s' not found", fn.ConfigMapRef.Name)
+			}
+			return fmt.Errorf("failed to fetch functions configmap '%s': %w", fn.ConfigMapRef.Name, err)
+		}
+		if _, ok := configMap.Data["index.ts"]; !ok {
+			return fmt.Errorf("functions configmap '%s' missing required key 'index.ts'", fn.ConfigMapRef.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validateAnalytics(project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Vector == nil || !project.Spec.Vector.Enabled {
+		return nil
+	}
+
+	if project.Spec.Analytics == nil || !project.Spec.Analytics.Enabled {
+		return fmt.Errorf("vector.enabled requires analytics.enabled")
+	}
+
+	return nil
+}
+
 func (r *SupabaseProjectWebhook) validateImages(project *supabasev1alpha1.SupabaseProject) error {
 	imagesToValidate := make(map[string]string)
 
@@ -219,6 +694,21 @@ func (r *SupabaseProjectWebhook) validateImages(project *supabasev1alpha1.Supaba
 	if project.Spec.StorageAPI != nil && project.Spec.StorageAPI.Image != "" {
 		imagesToValidate["storage"] = project.Spec.StorageAPI.Image
 	}
+	if project.Spec.Imgproxy != nil && project.Spec.Imgproxy.Image != "" {
+		imagesToValidate["imgproxy"] = project.Spec.Imgproxy.Image
+	}
+	if project.Spec.Functions != nil && project.Spec.Functions.Image != "" {
+		imagesToValidate["functions"] = project.Spec.Functions.Image
+	}
+	if project.Spec.Pooler != nil && project.Spec.Pooler.Image != "" {
+		imagesToValidate["pooler"] = project.Spec.Pooler.Image
+	}
+	if project.Spec.Analytics != nil && project.Spec.Analytics.Image != "" {
+		imagesToValidate["analytics"] = project.Spec.Analytics.Image
+	}
+	if project.Spec.Vector != nil && project.Spec.Vector.Image != "" {
+		imagesToValidate["vector"] = project.Spec.Vector.Image
+	}
 	if project.Spec.Meta != nil && project.Spec.Meta.Image != "" {
 		imagesToValidate["meta"] = project.Spec.Meta.Image
 	}
@@ -237,3 +727,157 @@ func (r *SupabaseProjectWebhook) validateImages(project *supabasev1alpha1.Supaba
 
 	return nil
 }
+
+func (r *SupabaseProjectWebhook) validateIngress(project *supabasev1alpha1.SupabaseProject) error {
+	ingress := project.Spec.Ingress
+	if ingress == nil || !ingress.Enabled {
+		return nil
+	}
+
+	if ingress.TLSSecretName != "" && ingress.Host == "" {
+		return fmt.Errorf("ingress.host is required when ingress.tlsSecretName is set")
+	}
+
+	return nil
+}
+
+func (r *SupabaseProjectWebhook) validateGateway(project *supabasev1alpha1.SupabaseProject) error {
+	gateway := project.Spec.Gateway
+	if gateway == nil || !gateway.Enabled {
+		return nil
+	}
+
+	if len(gateway.ParentRefs) == 0 {
+		return fmt.Errorf("gateway.parentRefs must contain at least one Gateway when gateway is enabled")
+	}
+	for i, ref := range gateway.ParentRefs {
+		if ref.Name == "" {
+			return fmt.Errorf("gateway.parentRefs[%d].name cannot be empty", i)
+		}
+	}
+
+	if tls := gateway.TLS; tls != nil {
+		if tls.Hostname == "" {
+			return fmt.Errorf("gateway.tls.hostname is required when gateway.tls is set")
+		}
+		hasRefs := len(tls.CACertificateRefs) > 0
+		hasWellKnown := tls.WellKnownCACertificates != ""
+		if hasRefs == hasWellKnown {
+			return fmt.Errorf("gateway.tls requires exactly one of caCertificateRefs or wellKnownCACertificates")
+		}
+	}
+
+	return nil
+}
+
+// validateKong keeps spec.kong.extraServices from taking over the routes of
+// the built-in services and checks spec.kong.rateLimits. Kong picks the
+// longest matching prefix, so a path under a built-in one, such as
+// /rest/v1/billing, would shadow part of it.
+func (r *SupabaseProjectWebhook) validateKong(project *supabasev1alpha1.SupabaseProject) error {
+	if project.Spec.Kong == nil {
+		return nil
+	}
+
+	builtinNames := component.KongBuiltinServiceNames()
+	builtinPaths := component.KongBuiltinPaths()
+	routed := map[string]string{}
+	for i, service := range project.Spec.Kong.ExtraServices {
+		if slices.Contains(builtinNames, service.Name) {
+			return fmt.Errorf("kong.extraServices[%d].name '%s' is used by a built-in service", i, service.Name)
+		}
+		for j, route := range service.Routes {
+			for k, path := range route.Paths {
+				field := fmt.Sprintf("kong.extraServices[%d].routes[%d].paths[%d]", i, j, k)
+				for _, builtin := range builtinPaths {
+					if kongPathsCollide(path, builtin) {
+						return fmt.Errorf("%s '%s' collides with the built-in path '%s'", field, path, builtin)
+					}
+				}
+				normalized := strings.TrimSuffix(path, "/")
+				if previous, ok := routed[normalized]; ok {
+					return fmt.Errorf("%s '%s' is already routed by %s", field, path, previous)
+				}
+				routed[normalized] = field
+			}
+		}
+	}
+
+	for i, plugin := range project.Spec.Kong.GlobalPlugins {
+		if plugin.Name == "pre-function" {
+			return fmt.Errorf("kong.globalPlugins[%d] 'pre-function' is used by the operator to revoke SupabaseAPIKeys", i)
+		}
+	}
+
+	for _, group := range component.KongRateLimitGroups(project.Spec.Kong.RateLimits) {
+		if err := validateKongRateLimit(group.Group, group.Limit); err != nil {
+			return err
+		}
+	}
+
+	if cors := project.Spec.Kong.CORS; cors != nil {
+		if err := validateKongCORSPolicy("kong.cors", cors.KongCORSPolicy); err != nil {
+			return err
+		}
+		for _, group := range component.KongCORSGroups(cors) {
+			if err := validateKongCORSPolicy("kong.cors.overrides."+group.Group, group.Policy); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateKongCORSPolicy rejects credentials next to the wildcard origin. Kong
+// answers such requests with the caller's origin, which would let any site
+// send authenticated requests. Overrides are checked after merging, since a
+// group can inherit either setting from the policy.
+func validateKongCORSPolicy(field string, policy supabasev1alpha1.KongCORSPolicy) error {
+	if policy.Credentials != nil && *policy.Credentials && slices.Contains(policy.Origins, "*") {
+		return fmt.Errorf("%s cannot allow credentials for the wildcard origin '*'", field)
+	}
+	return nil
+}
+
+// validateKongRateLimit mirrors the checks of Kong's rate-limiting plugin, which
+// would otherwise fail Kong's startup.
+func validateKongRateLimit(group string, limit *supabasev1alpha1.KongRateLimit) error {
+	if limit.Second == nil && limit.Minute == nil && limit.Hour == nil {
+		return fmt.Errorf("kong.rateLimits.%s must set at least one of second, minute or hour", group)
+	}
+
+	periods := []struct {
+		name  string
+		limit *int32
+	}{
+		{"second", limit.Second},
+		{"minute", limit.Minute},
+		{"hour", limit.Hour},
+	}
+	for i, shorter := range periods {
+		if shorter.limit == nil {
+			continue
+		}
+		for _, longer := range periods[i+1:] {
+			if longer.limit != nil && *longer.limit < *shorter.limit {
+				return fmt.Errorf("kong.rateLimits.%s.%s (%d) cannot be lower than kong.rateLimits.%s.%s (%d)",
+					group, longer.name, *longer.limit, group, shorter.name, *shorter.limit)
+			}
+		}
+	}
+
+	return nil
+}
+
+// kongPathsCollide reports whether path equals builtin or lies under it,
+// ignoring trailing slashes. Everything lies under the catch-all /, so only an
+// exact match collides with it.
+func kongPathsCollide(path, builtin string) bool {
+	path = strings.TrimSuffix(path, "/")
+	builtin = strings.TrimSuffix(builtin, "/")
+	if path == builtin {
+		return true
+	}
+	return builtin != "" && strings.HasPrefix(path, builtin+"/")
+}
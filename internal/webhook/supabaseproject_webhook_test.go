@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	supabasev1alpha1 "github.com/strrl/supabase-operator/api/v1alpha1"
+	"github.com/strrl/supabase-operator/internal/secrets"
 )
 
 // Helper function to create test secrets
@@ -410,6 +411,65 @@ func TestValidateCreate_ImageReferenceValidation(t *testing.T) {
 	}
 }
 
+func TestValidateCreate_ImgproxyImage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		image   string
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid imgproxy image",
+			image:   "darthsim/imgproxy:v3.8.0",
+			wantErr: false,
+		},
+		{
+			name:    "invalid image format should fail",
+			image:   "darthsim/imgproxy:v3.8.0 latest",
+			wantErr: true,
+			errMsg:  "invalid image reference format",
+		},
+		{
+			name:    "missing tag should fail",
+			image:   "darthsim/imgproxy",
+			wantErr: true,
+			errMsg:  "image must include tag (e.g., 'kong/kong:3.9.1')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Imgproxy = &supabasev1alpha1.ImgproxyConfig{Enabled: true, Image: tt.image}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
 func TestDefault_ResourceDefaults(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -453,3 +513,1568 @@ func TestDefault_ResourceDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreate_Ingress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		ingress *supabasev1alpha1.IngressConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "disabled ingress is not validated",
+			ingress: &supabasev1alpha1.IngressConfig{TLSSecretName: "supabase-tls"},
+			wantErr: false,
+		},
+		{
+			name: "enabled ingress with host and tls",
+			ingress: &supabasev1alpha1.IngressConfig{
+				Enabled:       true,
+				Host:          "supabase.example.com",
+				TLSSecretName: "supabase-tls",
+			},
+			wantErr: false,
+		},
+		{
+			name: "tls without host should fail",
+			ingress: &supabasev1alpha1.IngressConfig{
+				Enabled:       true,
+				TLSSecretName: "supabase-tls",
+			},
+			wantErr: true,
+			errMsg:  "ingress.host is required when ingress.tlsSecretName is set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Ingress = tt.ingress
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_Gateway(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		gateway *supabasev1alpha1.GatewayConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "disabled gateway is not validated",
+			gateway: &supabasev1alpha1.GatewayConfig{},
+			wantErr: false,
+		},
+		{
+			name: "enabled gateway with parentRef",
+			gateway: &supabasev1alpha1.GatewayConfig{
+				Enabled:    true,
+				ParentRefs: []supabasev1alpha1.GatewayParentReference{{Name: "public"}},
+				Hostnames:  []string{"supabase.example.com"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "enabled gateway without parentRefs should fail",
+			gateway: &supabasev1alpha1.GatewayConfig{Enabled: true},
+			wantErr: true,
+			errMsg:  "gateway.parentRefs must contain at least one Gateway when gateway is enabled",
+		},
+		{
+			name: "tls without hostname should fail",
+			gateway: &supabasev1alpha1.GatewayConfig{
+				Enabled:    true,
+				ParentRefs: []supabasev1alpha1.GatewayParentReference{{Name: "public"}},
+				TLS:        &supabasev1alpha1.GatewayTLSConfig{WellKnownCACertificates: "System"},
+			},
+			wantErr: true,
+			errMsg:  "gateway.tls.hostname is required when gateway.tls is set",
+		},
+		{
+			name: "tls without CA source should fail",
+			gateway: &supabasev1alpha1.GatewayConfig{
+				Enabled:    true,
+				ParentRefs: []supabasev1alpha1.GatewayParentReference{{Name: "public"}},
+				TLS:        &supabasev1alpha1.GatewayTLSConfig{Hostname: "kong.internal"},
+			},
+			wantErr: true,
+			errMsg:  "gateway.tls requires exactly one of caCertificateRefs or wellKnownCACertificates",
+		},
+		{
+			name: "tls with CA ref",
+			gateway: &supabasev1alpha1.GatewayConfig{
+				Enabled:    true,
+				ParentRefs: []supabasev1alpha1.GatewayParentReference{{Name: "public"}},
+				TLS: &supabasev1alpha1.GatewayTLSConfig{
+					Hostname:          "kong.internal",
+					CACertificateRefs: []string{"kong-ca"},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Gateway = tt.gateway
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_SMTPSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name       string
+		smtpSecret *corev1.Secret
+		wantErr    bool
+		errMsg     string
+	}{
+		{
+			name:    "missing smtp secret should fail",
+			wantErr: true,
+			errMsg:  "smtp secret 'smtp-config' not found",
+		},
+		{
+			name: "smtp secret missing from key should fail",
+			smtpSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "smtp-config", Namespace: "default"},
+				Data: map[string][]byte{
+					"host":     []byte("smtp.example.com"),
+					"port":     []byte("587"),
+					"username": []byte("mailer"),
+					"password": []byte("password"),
+				},
+			},
+			wantErr: true,
+			errMsg:  "smtp secret missing required key 'from'",
+		},
+		{
+			name: "valid smtp secret",
+			smtpSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "smtp-config", Namespace: "default"},
+				Data: map[string][]byte{
+					"host":     []byte("smtp.example.com"),
+					"port":     []byte("587"),
+					"username": []byte("mailer"),
+					"password": []byte("password"),
+					"from":     []byte("noreply@example.com"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := createTestSecrets()
+			if tt.smtpSecret != nil {
+				objects = append(objects, tt.smtpSecret)
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Auth = &supabasev1alpha1.AuthConfig{
+				SMTPSecretRef: &corev1.SecretReference{Name: "smtp-config"},
+			}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_StorageS3ProtocolSecret(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name     string
+		s3Secret *corev1.Secret
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:    "missing s3 protocol secret should fail",
+			wantErr: true,
+			errMsg:  "storage s3 protocol secret 'storage-s3-protocol' not found",
+		},
+		{
+			name: "s3 protocol secret missing secretAccessKey should fail",
+			s3Secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "storage-s3-protocol", Namespace: "default"},
+				Data: map[string][]byte{
+					"accessKeyId": []byte("key"),
+				},
+			},
+			wantErr: true,
+			errMsg:  "storage s3 protocol secret missing required key 'secretAccessKey'",
+		},
+		{
+			name: "valid s3 protocol secret",
+			s3Secret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "storage-s3-protocol", Namespace: "default"},
+				Data: map[string][]byte{
+					"accessKeyId":     []byte("key"),
+					"secretAccessKey": []byte("secret"),
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := createTestSecrets()
+			if tt.s3Secret != nil {
+				objects = append(objects, tt.s3Secret)
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.StorageAPI = &supabasev1alpha1.StorageAPIConfig{
+				S3ProtocolSecretRef: &corev1.SecretReference{Name: "storage-s3-protocol"},
+			}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_StorageFileBackend(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name     string
+		file     *supabasev1alpha1.StorageFileConfig
+		replicas int32
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:    "single replica on ReadWriteOnce",
+			file:    &supabasev1alpha1.StorageFileConfig{AccessMode: corev1.ReadWriteOnce},
+			wantErr: false,
+		},
+		{
+			name:     "multiple replicas on ReadWriteMany",
+			file:     &supabasev1alpha1.StorageFileConfig{AccessMode: corev1.ReadWriteMany},
+			replicas: 3,
+			wantErr:  false,
+		},
+		{
+			name:     "multiple replicas on ReadWriteOnce should fail",
+			file:     &supabasev1alpha1.StorageFileConfig{AccessMode: corev1.ReadWriteOnce},
+			replicas: 2,
+			wantErr:  true,
+			errMsg:   "storageApi.replicas (2) requires storage.file.accessMode ReadWriteMany",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// No storage secret: the file backend must not require one.
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()[0]).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Storage = supabasev1alpha1.StorageConfig{
+				Backend: "file",
+				File:    tt.file,
+			}
+			if tt.replicas > 0 {
+				project.Spec.StorageAPI = &supabasev1alpha1.StorageAPIConfig{Replicas: tt.replicas}
+			}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_Pooler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		pooler  *supabasev1alpha1.PoolerConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid pooler with routing",
+			pooler: &supabasev1alpha1.PoolerConfig{
+				Enabled:         true,
+				DefaultPoolSize: 20,
+				RouteComponents: []string{"postgrest"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "routing without pooler should fail",
+			pooler: &supabasev1alpha1.PoolerConfig{
+				RouteComponents: []string{"auth"},
+			},
+			wantErr: true,
+			errMsg:  "pooler.routeComponents requires pooler.enabled",
+		},
+		{
+			name: "pool size above max connections should fail",
+			pooler: &supabasev1alpha1.PoolerConfig{
+				Enabled:         true,
+				DefaultPoolSize: 50,
+			},
+			wantErr: true,
+			errMsg:  "pooler.defaultPoolSize (50) exceeds database.maxConnections (20)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Database.MaxConnections = 20
+			project.Spec.Pooler = tt.pooler
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_Analytics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name      string
+		analytics *supabasev1alpha1.AnalyticsConfig
+		vector    *supabasev1alpha1.VectorConfig
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name:      "analytics with vector",
+			analytics: &supabasev1alpha1.AnalyticsConfig{Enabled: true},
+			vector:    &supabasev1alpha1.VectorConfig{Enabled: true},
+			wantErr:   false,
+		},
+		{
+			name:    "disabled vector without analytics",
+			vector:  &supabasev1alpha1.VectorConfig{},
+			wantErr: false,
+		},
+		{
+			name:    "vector without analytics should fail",
+			vector:  &supabasev1alpha1.VectorConfig{Enabled: true},
+			wantErr: true,
+			errMsg:  "vector.enabled requires analytics.enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Analytics = tt.analytics
+			project.Spec.Vector = tt.vector
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_Functions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	helloConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "fn-hello", Namespace: "default"},
+		Data:       map[string]string{"index.ts": "Deno.serve(() => new Response('hello'))"},
+	}
+
+	tests := []struct {
+		name      string
+		functions *supabasev1alpha1.FunctionsConfig
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name: "configmap function",
+			functions: &supabasev1alpha1.FunctionsConfig{
+				Enabled: true,
+				Functions: []supabasev1alpha1.EdgeFunction{
+					{Name: "hello", ConfigMapRef: &corev1.LocalObjectReference{Name: "fn-hello"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing configmap should fail",
+			functions: &supabasev1alpha1.FunctionsConfig{
+				Enabled: true,
+				Functions: []supabasev1alpha1.EdgeFunction{
+					{Name: "hello", ConfigMapRef: &corev1.LocalObjectReference{Name: "fn-missing"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "functions configmap 'fn-missing' not found",
+		},
+		{
+			name: "function without source should fail",
+			functions: &supabasev1alpha1.FunctionsConfig{
+				Enabled:   true,
+				Functions: []supabasev1alpha1.EdgeFunction{{Name: "hello"}},
+			},
+			wantErr: true,
+			errMsg:  "functions.functions[0].configMapRef is required when functions.source is not set",
+		},
+		{
+			name: "both shared sources should fail",
+			functions: &supabasev1alpha1.FunctionsConfig{
+				Enabled: true,
+				Source: &supabasev1alpha1.FunctionsSource{
+					PersistentVolumeClaim: &supabasev1alpha1.FunctionsPVCSource{ClaimName: "functions"},
+					Image:                 &supabasev1alpha1.FunctionsImageSource{Reference: "ghcr.io/acme/functions:v1"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "functions.source must set exactly one of persistentVolumeClaim or image",
+		},
+		{
+			name: "configmap combined with shared source should fail",
+			functions: &supabasev1alpha1.FunctionsConfig{
+				Enabled: true,
+				Source: &supabasev1alpha1.FunctionsSource{
+					PersistentVolumeClaim: &supabasev1alpha1.FunctionsPVCSource{ClaimName: "functions"},
+				},
+				Functions: []supabasev1alpha1.EdgeFunction{
+					{Name: "hello", ConfigMapRef: &corev1.LocalObjectReference{Name: "fn-hello"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "functions.functions[0].configMapRef cannot be combined with functions.source",
+		},
+		{
+			name: "pvc source with per-function settings",
+			functions: &supabasev1alpha1.FunctionsConfig{
+				Enabled: true,
+				Source: &supabasev1alpha1.FunctionsSource{
+					PersistentVolumeClaim: &supabasev1alpha1.FunctionsPVCSource{ClaimName: "functions"},
+				},
+				Functions: []supabasev1alpha1.EdgeFunction{{Name: "hello"}},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := append(createTestSecrets(), helloConfigMap)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Functions = tt.functions
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_OAuthProviders(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	oauthSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oauth-secrets", Namespace: "default"},
+		Data: map[string][]byte{
+			"github": []byte("github-client-secret"),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		providers []supabasev1alpha1.OAuthProvider
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name: "valid github provider",
+			providers: []supabasev1alpha1.OAuthProvider{
+				{
+					Name:      "github",
+					Enabled:   true,
+					ClientID:  "client-id",
+					SecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth-secrets"}, Key: "github"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown provider should fail",
+			providers: []supabasev1alpha1.OAuthProvider{
+				{
+					Name:      "githib",
+					Enabled:   true,
+					ClientID:  "client-id",
+					SecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth-secrets"}, Key: "github"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "auth.providers[0].name 'githib' is not a supported OAuth provider",
+		},
+		{
+			name: "missing secret key should fail",
+			providers: []supabasev1alpha1.OAuthProvider{
+				{
+					Name:      "google",
+					Enabled:   true,
+					ClientID:  "client-id",
+					SecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth-secrets"}, Key: "google"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "oauth provider 'google' secret missing required key 'google'",
+		},
+		{
+			name: "missing secret should fail",
+			providers: []supabasev1alpha1.OAuthProvider{
+				{
+					Name:      "github",
+					Enabled:   true,
+					ClientID:  "client-id",
+					SecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "github"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "oauth provider 'github' secret 'missing' not found",
+		},
+		{
+			name: "keycloak without url should fail",
+			providers: []supabasev1alpha1.OAuthProvider{
+				{
+					Name:      "keycloak",
+					Enabled:   true,
+					ClientID:  "client-id",
+					SecretRef: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth-secrets"}, Key: "github"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "auth.providers[0].url is required for keycloak",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(createTestSecrets(), oauthSecret)...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Auth = &supabasev1alpha1.AuthConfig{Providers: tt.providers}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_SAML(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	samlSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "saml-key", Namespace: "default"},
+		Data: map[string][]byte{
+			"private-key": []byte("MIIEpAIBAAKCAQEA"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		saml    *supabasev1alpha1.SAMLConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "operator-generated key",
+			saml:    &supabasev1alpha1.SAMLConfig{Enabled: true},
+			wantErr: false,
+		},
+		{
+			name: "user-supplied key",
+			saml: &supabasev1alpha1.SAMLConfig{
+				Enabled: true,
+				PrivateKeySecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "saml-key"},
+					Key:                  "private-key",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "user-supplied secret missing key should fail",
+			saml: &supabasev1alpha1.SAMLConfig{
+				Enabled: true,
+				PrivateKeySecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "saml-key"},
+					Key:                  "tls.key",
+				},
+			},
+			wantErr: true,
+			errMsg:  "saml secret missing required key 'tls.key'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(createTestSecrets(), samlSecret)...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Auth = &supabasev1alpha1.AuthConfig{SAML: tt.saml}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_AuthSettings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		auth    *supabasev1alpha1.AuthConfig
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid settings",
+			auth: &supabasev1alpha1.AuthConfig{
+				SiteURL:                "https://app.example.com",
+				AdditionalRedirectURLs: []string{"https://app.example.com/**"},
+				JWTExpirySeconds:       3600,
+				PasswordMinLength:      8,
+				RateLimits:             &supabasev1alpha1.AuthRateLimits{EmailSent: 30},
+				MFA:                    &supabasev1alpha1.AuthMFAConfig{MaxEnrolledFactors: 10},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "relative site url should fail",
+			auth:    &supabasev1alpha1.AuthConfig{SiteURL: "/app"},
+			wantErr: true,
+			errMsg:  "auth.siteURL must be an absolute URL",
+		},
+		{
+			name:    "redirect url with comma should fail",
+			auth:    &supabasev1alpha1.AuthConfig{AdditionalRedirectURLs: []string{"https://a.example.com,https://b.example.com"}},
+			wantErr: true,
+			errMsg:  "auth.additionalRedirectURLs[0] must be a non-empty URL without commas",
+		},
+		{
+			name:    "jwt expiry too long should fail",
+			auth:    &supabasev1alpha1.AuthConfig{JWTExpirySeconds: 604801},
+			wantErr: true,
+			errMsg:  "auth.jwtExpirySeconds must be between 30 and 604800",
+		},
+		{
+			name:    "password min length too short should fail",
+			auth:    &supabasev1alpha1.AuthConfig{PasswordMinLength: 4},
+			wantErr: true,
+			errMsg:  "auth.passwordMinLength must be between 6 and 72",
+		},
+		{
+			name:    "negative rate limit should fail",
+			auth:    &supabasev1alpha1.AuthConfig{RateLimits: &supabasev1alpha1.AuthRateLimits{OTP: -1}},
+			wantErr: true,
+			errMsg:  "auth.rateLimits.otp must not be negative",
+		},
+		{
+			name:    "too many mfa factors should fail",
+			auth:    &supabasev1alpha1.AuthConfig{MFA: &supabasev1alpha1.AuthMFAConfig{MaxEnrolledFactors: 101}},
+			wantErr: true,
+			errMsg:  "auth.mfa.maxEnrolledFactors must be between 1 and 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Auth = tt.auth
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_AuthHooks(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	hookSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "auth-hooks", Namespace: "default"},
+		Data: map[string][]byte{
+			"send-email": []byte("v1,whsec_c2VjcmV0"),
+		},
+	}
+	hookSecretRef := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "auth-hooks"},
+		Key:                  "send-email",
+	}
+
+	tests := []struct {
+		name    string
+		hook    supabasev1alpha1.AuthHook
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "pg-functions hook",
+			hook:    supabasev1alpha1.AuthHook{Type: "custom_access_token", URI: "pg-functions://postgres/public/hook"},
+			wantErr: false,
+		},
+		{
+			name:    "malformed pg-functions hook should fail",
+			hook:    supabasev1alpha1.AuthHook{Type: "custom_access_token", URI: "pg-functions://postgres/hook"},
+			wantErr: true,
+			errMsg:  "auth.hooks[0].uri: hook uri must have the form pg-functions://<database>/<schema>/<function>",
+		},
+		{
+			name:    "http hook with secret",
+			hook:    supabasev1alpha1.AuthHook{Type: "send_email", URI: "https://hooks.example.com/send-email", SecretRef: hookSecretRef},
+			wantErr: false,
+		},
+		{
+			name:    "http hook without secret should fail",
+			hook:    supabasev1alpha1.AuthHook{Type: "send_email", URI: "https://hooks.example.com/send-email"},
+			wantErr: true,
+			errMsg:  "auth.hooks[0].secretRef is required for HTTP hooks",
+		},
+		{
+			name:    "unsupported scheme should fail",
+			hook:    supabasev1alpha1.AuthHook{Type: "send_email", URI: "ftp://hooks.example.com"},
+			wantErr: true,
+			errMsg:  "auth.hooks[0].uri must be a pg-functions:// URI or an http(s) URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(createTestSecrets(), hookSecret)...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Auth = &supabasev1alpha1.AuthConfig{Hooks: []supabasev1alpha1.AuthHook{tt.hook}}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_PostgREST(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		postgrest      *supabasev1alpha1.PostgRESTConfig
+		maxConnections int
+		wantErr        bool
+		errMsg         string
+	}{
+		{
+			name: "valid settings",
+			postgrest: &supabasev1alpha1.PostgRESTConfig{
+				Replicas:       2,
+				ExposedSchemas: []string{"public", "api"},
+				DBPool:         10,
+			},
+			maxConnections: 20,
+			wantErr:        false,
+		},
+		{
+			name:      "empty schema should fail",
+			postgrest: &supabasev1alpha1.PostgRESTConfig{ExposedSchemas: []string{"public", ""}},
+			wantErr:   true,
+			errMsg:    "postgrest.exposedSchemas[1] must be a non-empty schema name",
+		},
+		{
+			name: "pool exceeding max connections should fail",
+			postgrest: &supabasev1alpha1.PostgRESTConfig{
+				Replicas: 3,
+				DBPool:   10,
+			},
+			maxConnections: 20,
+			wantErr:        true,
+			errMsg:         "postgrest.dbPool (10) x replicas (3) exceeds database.maxConnections (20)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.PostgREST = tt.postgrest
+			project.Spec.Database.MaxConnections = tt.maxConnections
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_JWTSecretRef(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	jwtSecret, err := secrets.GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+	otherSecret, err := secrets.GenerateJWTSecret()
+	if err != nil {
+		t.Fatalf("GenerateJWTSecret() error = %v", err)
+	}
+	anonKey, err := secrets.GenerateAnonKey(jwtSecret)
+	if err != nil {
+		t.Fatalf("GenerateAnonKey() error = %v", err)
+	}
+	serviceRoleKey, err := secrets.GenerateServiceRoleKey(jwtSecret)
+	if err != nil {
+		t.Fatalf("GenerateServiceRoleKey() error = %v", err)
+	}
+	foreignAnonKey, err := secrets.GenerateAnonKey(otherSecret)
+	if err != nil {
+		t.Fatalf("GenerateAnonKey() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		data     map[string][]byte
+		ref      *corev1.SecretReference
+		rotation *supabasev1alpha1.JWTRotationConfig
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:    "secret only",
+			data:    map[string][]byte{"jwt-secret": []byte(jwtSecret)},
+			wantErr: false,
+		},
+		{
+			name: "secret with matching keys",
+			data: map[string][]byte{
+				"jwt-secret":       []byte(jwtSecret),
+				"anon-key":         []byte(anonKey),
+				"service-role-key": []byte(serviceRoleKey),
+			},
+			wantErr: false,
+		},
+		{
+			name:    "missing secret should fail",
+			data:    map[string][]byte{"jwt-secret": []byte(jwtSecret)},
+			ref:     &corev1.SecretReference{Name: "missing-jwt"},
+			wantErr: true,
+			errMsg:  "jwt secret 'missing-jwt' not found",
+		},
+		{
+			name:    "missing jwt-secret key should fail",
+			data:    map[string][]byte{"anon-key": []byte(anonKey)},
+			wantErr: true,
+			errMsg:  "jwt secret missing required key 'jwt-secret'",
+		},
+		{
+			name:    "non-base64 jwt-secret should fail",
+			data:    map[string][]byte{"jwt-secret": []byte("not base64!")},
+			wantErr: true,
+		},
+		{
+			name: "anon key signed by another secret should fail",
+			data: map[string][]byte{
+				"jwt-secret": []byte(jwtSecret),
+				"anon-key":   []byte(foreignAnonKey),
+			},
+			wantErr: true,
+		},
+		{
+			name:     "rotation with secretRef should fail",
+			data:     map[string][]byte{"jwt-secret": []byte(jwtSecret)},
+			rotation: &supabasev1alpha1.JWTRotationConfig{Trigger: "2026-01-01"},
+			wantErr:  true,
+			errMsg:   "jwt.rotation cannot be combined with jwt.secretRef, rotate the referenced secret instead",
+		},
+		{
+			name: "keys with swapped roles should fail",
+			data: map[string][]byte{
+				"jwt-secret":       []byte(jwtSecret),
+				"service-role-key": []byte(anonKey),
+			},
+			wantErr: true,
+			errMsg:  "jwt secret key 'service-role-key' is not signed by 'jwt-secret': token role is anon, expected service_role",
+		},
+		{
+			name: "secret with opaque keys",
+			data: map[string][]byte{
+				"jwt-secret":      []byte(jwtSecret),
+				"publishable-key": []byte("sb_publishable_ACJWlzQHlZjBrEguHvfOxg_3BJgxAaH"),
+				"secret-key":      []byte("sb_secret_N7UND0UgjKTVK-Uodkm0Hg_xSvEMPvz"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "opaque keys with swapped prefixes should fail",
+			data: map[string][]byte{
+				"jwt-secret":      []byte(jwtSecret),
+				"publishable-key": []byte("sb_secret_N7UND0UgjKTVK-Uodkm0Hg_xSvEMPvz"),
+			},
+			wantErr: true,
+			errMsg:  "jwt secret key 'publishable-key' is invalid: key must start with \"sb_publishable_\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtUserSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "existing-jwt", Namespace: "default"},
+				Data:       tt.data,
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append(createTestSecrets(), jwtUserSecret)...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			ref := tt.ref
+			if ref == nil {
+				ref = &corev1.SecretReference{Name: "existing-jwt"}
+			}
+
+			project := createTestProject()
+			project.Spec.JWT = &supabasev1alpha1.JWTConfig{SecretRef: ref, Rotation: tt.rotation}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_APIKeyLifetime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	int32Ptr := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name        string
+		ttl         *int32
+		renewBefore *int32
+		wantErr     bool
+		errMsg      string
+	}{
+		{
+			name:        "one year keys renewed a month ahead",
+			ttl:         int32Ptr(31536000),
+			renewBefore: int32Ptr(2592000),
+			wantErr:     false,
+		},
+		{
+			name:        "renewal threshold alone uses the default TTL",
+			renewBefore: int32Ptr(31536000),
+			wantErr:     false,
+		},
+		{
+			name:        "renewal threshold equal to TTL should fail",
+			ttl:         int32Ptr(86400),
+			renewBefore: int32Ptr(86400),
+			wantErr:     true,
+			errMsg:      "jwt.apiKeyRenewBeforeSeconds (86400) must be less than jwt.apiKeyTTL (86400)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.JWT = &supabasev1alpha1.JWTConfig{APIKeyTTL: tt.ttl, APIKeyRenewBeforeSeconds: tt.renewBefore}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_KongExtraServices(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	service := func(name string, paths ...string) supabasev1alpha1.KongService {
+		return supabasev1alpha1.KongService{
+			Name:   name,
+			URL:    "http://" + name + ".default.svc:8080",
+			Routes: []supabasev1alpha1.KongRoute{{Paths: paths}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		services []supabasev1alpha1.KongService
+		wantErr  bool
+		errMsg   string
+	}{
+		{
+			name:     "services next to the built-in paths",
+			services: []supabasev1alpha1.KongService{service("billing", "/billing/v1"), service("reports", "/reports", "/auth")},
+			wantErr:  false,
+		},
+		{
+			name:     "built-in service name should fail",
+			services: []supabasev1alpha1.KongService{service("rest-v1", "/billing/v1")},
+			wantErr:  true,
+			errMsg:   "kong.extraServices[0].name 'rest-v1' is used by a built-in service",
+		},
+		{
+			name:     "path under a built-in path should fail",
+			services: []supabasev1alpha1.KongService{service("billing", "/rest/v1/billing")},
+			wantErr:  true,
+			errMsg:   "kong.extraServices[0].routes[0].paths[0] '/rest/v1/billing' collides with the built-in path '/rest/v1/'",
+		},
+		{
+			name:     "catch-all path should fail",
+			services: []supabasev1alpha1.KongService{service("billing", "/")},
+			wantErr:  true,
+			errMsg:   "kong.extraServices[0].routes[0].paths[0] '/' collides with the built-in path '/'",
+		},
+		{
+			name:     "path routed twice should fail",
+			services: []supabasev1alpha1.KongService{service("billing", "/billing"), service("invoices", "/billing/")},
+			wantErr:  true,
+			errMsg:   "kong.extraServices[1].routes[0].paths[0] '/billing/' is already routed by kong.extraServices[0].routes[0].paths[0]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Kong = &supabasev1alpha1.KongConfig{ExtraServices: tt.services}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_KongGlobalPlugins(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		plugins []supabasev1alpha1.KongPlugin
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "global plugins",
+			plugins: []supabasev1alpha1.KongPlugin{{Name: "correlation-id"}, {Name: "post-function"}},
+			wantErr: false,
+		},
+		{
+			name:    "pre-function should fail",
+			plugins: []supabasev1alpha1.KongPlugin{{Name: "correlation-id"}, {Name: "pre-function"}},
+			wantErr: true,
+			errMsg:  "kong.globalPlugins[1] 'pre-function' is used by the operator to revoke SupabaseAPIKeys",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Kong = &supabasev1alpha1.KongConfig{GlobalPlugins: tt.plugins}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_KongCORS(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	allow, deny := true, false
+	tests := []struct {
+		name    string
+		cors    *supabasev1alpha1.KongCORS
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "credentials for listed origins",
+			cors: &supabasev1alpha1.KongCORS{
+				KongCORSPolicy: supabasev1alpha1.KongCORSPolicy{Origins: []string{"https://app.example.com"}, Credentials: &allow},
+			},
+			wantErr: false,
+		},
+		{
+			name: "credentials for the wildcard origin should fail",
+			cors: &supabasev1alpha1.KongCORS{
+				KongCORSPolicy: supabasev1alpha1.KongCORSPolicy{Origins: []string{"*"}, Credentials: &allow},
+			},
+			wantErr: true,
+			errMsg:  "kong.cors cannot allow credentials for the wildcard origin '*'",
+		},
+		{
+			name: "override opening the origins of a policy with credentials should fail",
+			cors: &supabasev1alpha1.KongCORS{
+				KongCORSPolicy: supabasev1alpha1.KongCORSPolicy{Origins: []string{"https://app.example.com"}, Credentials: &allow},
+				Overrides: &supabasev1alpha1.KongCORSOverrides{
+					Storage: &supabasev1alpha1.KongCORSPolicy{Origins: []string{"*"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "kong.cors.overrides.storage cannot allow credentials for the wildcard origin '*'",
+		},
+		{
+			name: "override allowing credentials on a wildcard policy should fail",
+			cors: &supabasev1alpha1.KongCORS{
+				KongCORSPolicy: supabasev1alpha1.KongCORSPolicy{Origins: []string{"*"}},
+				Overrides: &supabasev1alpha1.KongCORSOverrides{
+					Auth: &supabasev1alpha1.KongCORSPolicy{Credentials: &allow},
+				},
+			},
+			wantErr: true,
+			errMsg:  "kong.cors.overrides.auth cannot allow credentials for the wildcard origin '*'",
+		},
+		{
+			name: "override with its own origins or without credentials",
+			cors: &supabasev1alpha1.KongCORS{
+				KongCORSPolicy: supabasev1alpha1.KongCORSPolicy{Origins: []string{"https://app.example.com"}, Credentials: &allow},
+				Overrides: &supabasev1alpha1.KongCORSOverrides{
+					Rest:    &supabasev1alpha1.KongCORSPolicy{Origins: []string{"https://admin.example.com"}},
+					Storage: &supabasev1alpha1.KongCORSPolicy{Origins: []string{"*"}, Credentials: &deny},
+				},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Kong = &supabasev1alpha1.KongConfig{CORS: tt.cors}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateCreate_KongRateLimits(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = supabasev1alpha1.AddToScheme(scheme)
+
+	int32Ptr := func(v int32) *int32 { return &v }
+
+	tests := []struct {
+		name    string
+		limits  *supabasev1alpha1.KongRateLimits
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "limits per second and minute",
+			limits: &supabasev1alpha1.KongRateLimits{
+				Auth: &supabasev1alpha1.KongRateLimit{Minute: int32Ptr(30)},
+				Rest: &supabasev1alpha1.KongRateLimit{Second: int32Ptr(10), Minute: int32Ptr(300)},
+			},
+			wantErr: false,
+		},
+		{
+			name: "limit without a period should fail",
+			limits: &supabasev1alpha1.KongRateLimits{
+				Storage: &supabasev1alpha1.KongRateLimit{LimitBy: "consumer"},
+			},
+			wantErr: true,
+			errMsg:  "kong.rateLimits.storage must set at least one of second, minute or hour",
+		},
+		{
+			name: "hour below second should fail",
+			limits: &supabasev1alpha1.KongRateLimits{
+				Rest: &supabasev1alpha1.KongRateLimit{Second: int32Ptr(100), Hour: int32Ptr(50)},
+			},
+			wantErr: true,
+			errMsg:  "kong.rateLimits.rest.hour (50) cannot be lower than kong.rateLimits.rest.second (100)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(createTestSecrets()...).
+				Build()
+
+			webhook := &SupabaseProjectWebhook{
+				Client: fakeClient,
+			}
+
+			project := createTestProject()
+			project.Spec.Kong = &supabasev1alpha1.KongConfig{RateLimits: tt.limits}
+
+			_, err := webhook.ValidateCreate(context.Background(), project)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCreate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && err != nil && tt.errMsg != "" {
+				if err.Error() != tt.errMsg {
+					t.Errorf("ValidateCreate() error message = %v, want %v", err.Error(), tt.errMsg)
+				}
+			}
+		})
+	}
+}